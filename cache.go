@@ -0,0 +1,131 @@
+package goyek
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// CacheDir is the directory, relative to the working directory,
+// in which RunOnce stores the results of the cacheable tasks.
+const CacheDir = ".taskflow-cache"
+
+// cacheEntry is the content of a task's cache file.
+type cacheEntry struct {
+	Time time.Time `json:"time"` // the time of the last successful run
+	Hash string    `json:"hash"` // the hash of the task's parameter values and input files
+}
+
+// cachedAction returns the action which is skipped if the task's cache is up to date
+// and updates the task's cache after the action passes.
+func cachedAction(task Task, action func(tf *TF)) func(tf *TF) {
+	return func(tf *TF) {
+		// the path is absolute as the action may change the working directory, e.g. using WorkDir
+		path, err := filepath.Abs(filepath.Join(CacheDir, url.QueryEscape(task.Name)+".json"))
+		if err != nil {
+			tf.Fatalf("cannot get cache path: %v", err)
+		}
+		hash, err := taskHash(tf, task)
+		if err != nil {
+			tf.Fatalf("cannot hash task inputs: %v", err)
+		}
+		if entry, err := readCacheEntry(path); err == nil && entry.Hash == hash && outputsExist(task) {
+			tf.Skipf("cached since %s", entry.Time.Format(time.RFC3339))
+		}
+
+		action(tf)
+
+		if tf.Failed() {
+			return
+		}
+		if err := writeCacheEntry(path, cacheEntry{Time: time.Now(), Hash: hash}); err != nil {
+			tf.Errorf("cannot write cache: %v", err)
+		}
+	}
+}
+
+// taskHash returns the hash of the values of the task's parameters
+// and the names and contents of the task's input files.
+// The files in the directories matched by the input patterns are included.
+func taskHash(tf *TF, task Task) (string, error) {
+	names := make([]string, 0, len(task.Params))
+	for _, param := range task.Params {
+		names = append(names, param.Name())
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\n", name, tf.paramValues[name].String())
+	}
+	for _, pattern := range task.Inputs {
+		matches, err := filepath.Glob(taskPath(task, pattern))
+		if err != nil {
+			return "", err
+		}
+		for _, match := range matches {
+			// the files in a matched directory are hashed in lexical order
+			err := filepath.Walk(match, func(file string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				content, err := ioutil.ReadFile(file) //nolint:gosec // the file is provided by the taskflow's author
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(h, "%s\n%d\n", file, len(content))
+				h.Write(content) //nolint // hash.Hash never returns an error
+				return nil
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// outputsExist reports whether all output files of the task exist.
+func outputsExist(task Task) bool {
+	for _, output := range task.Outputs {
+		if _, err := os.Stat(taskPath(task, output)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// taskPath returns the path resolved against the task's WorkDir if it is set.
+func taskPath(task Task, path string) string {
+	if task.WorkDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(task.WorkDir, path)
+}
+
+func readCacheEntry(path string) (cacheEntry, error) {
+	var entry cacheEntry
+	data, err := ioutil.ReadFile(path) //nolint:gosec // the path is built from the task's name
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
+
+func writeCacheEntry(path string, entry cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil { //nolint:gomnd // permissions
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600) //nolint:gomnd // permissions
+}
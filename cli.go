@@ -2,22 +2,42 @@ package goyek
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"syscall"
 )
 
 // Main parses the command-line arguments and runs the provided tasks.
 // The usage is printed when invalid arguments are passed.
+// The run is canceled on the first interrupt (Ctrl+C) or termination signal
+// and the process exits immediately with code 130 on the second interrupt.
 func (f *Taskflow) Main() {
-	// trap Ctrl+C and call cancel on the context
+	if f.parent != nil {
+		f.parent.Main()
+		return
+	}
+	// the output is shared by the run and the signal handler below
+	out := &syncWriter{Writer: f.Writer()}
+	f.Output = out
+
+	// trap Ctrl+C and SIGTERM and call cancel on the context
 	ctx, cancel := context.WithCancel(context.Background())
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	c := make(chan os.Signal, 2) //nolint:gomnd // the second signal exits the process
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		select {
-		case <-c:
+		case sig := <-c:
+			fmt.Fprintf(out, "%v signal received, waiting for running tasks to finish...\n", sig)
 			cancel()
 		case <-ctx.Done():
+			return
+		}
+		// another termination signal is ignored as the run is already canceled
+		for sig := range c {
+			if sig == os.Interrupt {
+				os.Exit(exitCodeInterrupted)
+			}
 		}
 	}()
 
@@ -25,3 +45,6 @@ func (f *Taskflow) Main() {
 	exitCode := f.Run(ctx, os.Args[1:]...)
 	os.Exit(exitCode)
 }
+
+// exitCodeInterrupted is the exit code used when the process is interrupted twice.
+const exitCodeInterrupted = 130
@@ -0,0 +1,46 @@
+package goyek
+
+import (
+	"io"
+	"os"
+)
+
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+)
+
+// useColor reports whether the output should be colored.
+// If color is nil, then it is enabled only when w is a terminal.
+func useColor(color *bool, w io.Writer) bool {
+	if color != nil {
+		return *color
+	}
+	if term := os.Getenv("TERM"); term == "" || term == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// colorStatus returns the task's status wrapped in the ANSI color codes.
+func colorStatus(status string) string {
+	switch status {
+	case "PASS":
+		return colorGreen + status + colorReset
+	case "FAIL":
+		return colorRed + status + colorReset
+	case "SKIP":
+		return colorYellow + status + colorReset
+	}
+	return status
+}
@@ -0,0 +1,68 @@
+package goyek
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// completionFlag is the built-in flag which prints the shell completion script.
+const completionFlag = "-completion"
+
+// CompletionScript writes a shell completion script which completes
+// the names of the registered tasks and parameters.
+// The supported shells are "bash", "zsh" and "fish".
+// The script is also printed when the taskflow is run with -completion=<shell>.
+func (f *Taskflow) CompletionScript(shell string, w io.Writer) error {
+	return f.runner().writeCompletionScript(shell, w)
+}
+
+var nonIdentifierRegex = regexp.MustCompile("[^a-zA-Z0-9_]")
+
+func (f *flowRunner) writeCompletionScript(shell string, w io.Writer) error {
+	prog := filepath.Base(os.Args[0])
+	fn := "_" + nonIdentifierRegex.ReplaceAllString(prog, "_") + "_completion"
+
+	tasks := sortedTaskNames(f.tasks)
+	params := make([]string, 0, len(f.params))
+	for name, param := range f.params {
+		if !param.envOnly {
+			params = append(params, name)
+		}
+	}
+	sort.Strings(params)
+	flags := make([]string, len(params))
+	for i, name := range params {
+		flags[i] = "-" + name
+	}
+	words := strings.Join(append(tasks, flags...), " ")
+
+	sb := &strings.Builder{}
+	switch shell {
+	case "bash":
+		fmt.Fprintf(sb, "%s() {\n", fn)
+		fmt.Fprintf(sb, "\tCOMPREPLY=($(compgen -W %q -- \"${COMP_WORDS[COMP_CWORD]}\"))\n", words)
+		fmt.Fprintf(sb, "}\n")
+		fmt.Fprintf(sb, "complete -F %s %s\n", fn, prog)
+	case "zsh":
+		fmt.Fprintf(sb, "#compdef %s\n", prog)
+		fmt.Fprintf(sb, "%s() {\n", fn)
+		fmt.Fprintf(sb, "\tcompadd -- %s\n", words)
+		fmt.Fprintf(sb, "}\n")
+		fmt.Fprintf(sb, "compdef %s %s\n", fn, prog)
+	case "fish":
+		fmt.Fprintf(sb, "complete -c %s -f -a %q\n", prog, strings.Join(tasks, " "))
+		for _, name := range params {
+			fmt.Fprintf(sb, "complete -c %s -o %s -d %q\n", prog, name, f.params[name].usage)
+		}
+	default:
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
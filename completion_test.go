@@ -0,0 +1,47 @@
+package goyek_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_CompletionScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		shell := shell
+		t.Run(shell, func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			flow.Register(goyek.Task{Name: "my-task"})
+			sb := &strings.Builder{}
+
+			err := flow.CompletionScript(shell, sb)
+
+			requireEqual(t, err, nil, "should not return an error")
+			assertContains(t, sb.String(), "my-task", "should complete the task")
+			assertContains(t, sb.String(), "wd", "should complete the built-in parameter")
+		})
+	}
+}
+
+func Test_CompletionScript_unknown_shell(t *testing.T) {
+	flow := &goyek.Taskflow{}
+
+	err := flow.CompletionScript("cmd", &strings.Builder{})
+
+	assertEqual(t, err.Error(), "unsupported shell: cmd", "should return an error")
+}
+
+func Test_completion_flag(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	flow.Register(goyek.Task{Name: "my-task"})
+
+	exitCode := flow.Run(context.Background(), "-completion=bash")
+	invalidExitCode := flow.Run(context.Background(), "-completion=cmd")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, invalidExitCode, goyek.CodeInvalidArgs, "should fail for an unknown shell")
+	assertContains(t, sb.String(), "complete -F", "should print the completion script")
+}
@@ -0,0 +1,28 @@
+package goyek
+
+import "context"
+
+// ContextTaskflow is a taskflow bound to a context.
+// It embeds the taskflow, so the tasks and parameters can still be registered through it.
+type ContextTaskflow struct {
+	*Taskflow
+	ctx context.Context
+}
+
+// WithContext returns the taskflow bound to the given context,
+// e.g. a context canceled on a signal, which is used by its Run method.
+// The returned value shares the tasks, parameters, and fields with the taskflow.
+func (f *Taskflow) WithContext(ctx context.Context) *ContextTaskflow {
+	return &ContextTaskflow{Taskflow: f, ctx: ctx}
+}
+
+// Context returns the context to which the taskflow is bound.
+func (f *ContextTaskflow) Context() context.Context {
+	return f.ctx
+}
+
+// Run runs provided tasks and all their dependencies using the bound context.
+// Each task is executed at most once.
+func (f *ContextTaskflow) Run(args ...string) int {
+	return f.Taskflow.Run(f.ctx, args...)
+}
@@ -0,0 +1,37 @@
+package goyek_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_WithContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	flow := (&goyek.Taskflow{Output: &strings.Builder{}}).WithContext(ctx)
+	var got interface{}
+	flow.Register(goyek.Task{
+		Name:   "task",
+		Action: func(tf *goyek.TF) { got = tf.Context().Value(key{}) },
+	})
+
+	exitCode := flow.Run("task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, got, "value", "should run the task with the bound context")
+	assertEqual(t, flow.Context(), ctx, "should return the bound context")
+}
+
+func Test_WithContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	flow := (&goyek.Taskflow{Output: &strings.Builder{}}).WithContext(ctx)
+	flow.Register(goyek.Task{Name: "task", Action: func(tf *goyek.TF) {}})
+
+	exitCode := flow.Run("task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail when the bound context is canceled")
+}
@@ -0,0 +1,60 @@
+package goyek
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Export returns the representation of the registered tasks and parameters
+// which can be consumed by external tools, e.g. marshaled to JSON.
+// It contains only plain Go types. The "tasks" key holds a []map[string]interface{}
+// with the "name", "usage", "deps", "params", and "labels" keys.
+// The "params" key holds a []map[string]interface{} with the "name", "usage", "type",
+// and "default" keys. Both are sorted by name.
+func (f *Taskflow) Export() map[string]interface{} {
+	tasks := []map[string]interface{}{}
+	for _, name := range sortedTaskNames(f.tasks) {
+		task := f.tasks[name]
+		deps := []string{}
+		for _, dep := range task.Deps {
+			deps = append(deps, dep.name)
+		}
+		params := []string{}
+		for _, param := range task.Params {
+			params = append(params, param.Name())
+		}
+		labels := map[string]string{}
+		for key, value := range task.Labels {
+			labels[key] = value
+		}
+		tasks = append(tasks, map[string]interface{}{
+			"name":   task.Name,
+			"usage":  task.Usage,
+			"deps":   deps,
+			"params": params,
+			"labels": labels,
+		})
+	}
+
+	paramNames := make([]string, 0, len(f.params))
+	for name := range f.params {
+		paramNames = append(paramNames, name)
+	}
+	sort.Strings(paramNames)
+	params := []map[string]interface{}{}
+	for _, name := range paramNames {
+		param := f.params[name]
+		value := param.newValue()
+		params = append(params, map[string]interface{}{
+			"name":    param.name,
+			"usage":   param.usage,
+			"type":    fmt.Sprintf("%T", value.Get()),
+			"default": value.String(),
+		})
+	}
+
+	return map[string]interface{}{
+		"tasks":  tasks,
+		"params": params,
+	}
+}
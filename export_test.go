@@ -0,0 +1,43 @@
+package goyek_test
+
+import (
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_Export(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	param := flow.RegisterIntParam(goyek.IntParam{Name: "count", Usage: "Count", Default: 3})
+	task1 := flow.Register(goyek.Task{Name: "task-1", Usage: "First", Params: goyek.Params{param}})
+	flow.Register(goyek.Task{Name: "task-2", Deps: goyek.Deps{task1}, Labels: map[string]string{"kind": "ci"}})
+
+	got := flow.Export()
+
+	assertEqual(t, got, map[string]interface{}{
+		"tasks": []map[string]interface{}{
+			{
+				"name":   "task-1",
+				"usage":  "First",
+				"deps":   []string{},
+				"params": []string{"count"},
+				"labels": map[string]string{},
+			},
+			{
+				"name":   "task-2",
+				"usage":  "",
+				"deps":   []string{"task-1"},
+				"params": []string{},
+				"labels": map[string]string{"kind": "ci"},
+			},
+		},
+		"params": []map[string]interface{}{
+			{
+				"name":    "count",
+				"usage":   "Count",
+				"type":    "int",
+				"default": "3",
+			},
+		},
+	}, "should export the tasks and parameters")
+}
@@ -2,43 +2,95 @@ package goyek
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"runtime/trace"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
 )
 
 type flowRunner struct {
-	output      io.Writer
-	params      map[string]registeredParam
-	paramValues map[string]ParamValue
-	tasks       map[string]Task
-	verbose     RegisteredBoolParam
-	workDir     RegisteredStringParam
-	defaultTask RegisteredTask
+	output       io.Writer
+	color        bool
+	params       map[string]registeredParam
+	paramValues  map[string]ParamValue
+	explicit     map[string]bool // parameters set via CLI or their environment variables
+	fromEnv      map[string]bool // parameters set via their environment variables and not yet via CLI
+	tasks        map[string]Task
+	verbosity    RegisteredIntParam
+	workDir      RegisteredStringParam
+	plan         RegisteredBoolParam
+	defaultTasks []RegisteredTask
+	onTaskStart  func(name string)
+	onTaskEnd    func(name string, result TaskResult)
+	listeners    []ExecutionListener
+	quiet        bool
+	streamAfter  time.Duration
+	useCache     bool // skip the cacheable tasks which are up to date
+	literalStdin bool // keep "-" as a task name instead of reading the task names from stdin
+	prefixOutput bool
+	printGantt   bool
+	maxFailures  int
+	usageWidth   int
+	jsonLines    bool
+	traceFile    string
+	reportJUnit  string
+	jsonMtx      sync.Mutex
+	store        *sync.Map // values shared between the tasks
+
+	statsMtx sync.Mutex
+	stats    RunStats
+	taskErr  *TaskError // the failure of the task which stopped the run
 }
 
 // Run runs provided tasks and all their dependencies.
 // Each task is executed at most once.
 func (f *flowRunner) Run(ctx context.Context, args []string) int {
+	parsed, ok := f.setUp(args)
+	if !ok {
+		return CodeInvalidArgs
+	}
+	return f.runParsed(ctx, parsed)
+}
+
+// setUp initializes the parameters and parses the arguments.
+// It reports false if the parameters cannot be set.
+func (f *flowRunner) setUp(args []string) (parsedArgs, bool) {
 	f.verifyAllParametersAreInUse()
 	f.initializeParameters()
-	tasks, usageRequested, err := f.parseArguments(args)
+	if err := f.setEnvValues(); err != nil {
+		fmt.Fprintf(f.output, "cannot set parameters: %v\n", err)
+		return parsedArgs{}, false
+	}
+	parsed, err := f.parseArguments(args)
 	if err != nil {
 		fmt.Fprintf(f.output, "cannot parse arguments: %v\n", err)
-		return CodeInvalidArgs
+		return parsedArgs{}, false
 	}
+	return parsed, true
+}
 
-	if usageRequested {
-		printUsage(f)
-		return CodePass
+// runParsed runs the taskflow for the parsed command-line arguments.
+func (f *flowRunner) runParsed(ctx context.Context, parsed parsedArgs) int {
+	if parsed.usageRequested || parsed.listRequested || parsed.completion != "" {
+		return f.printRequested(parsed)
 	}
 
-	tasks = f.tasksToRun(tasks)
+	tasks := f.tasksToRun(parsed.tasks)
+	if parsed.labels != nil {
+		var err error
+		if tasks, err = f.tasksWithLabels(parsed.tasks, parsed.labels); err != nil {
+			fmt.Fprintf(f.output, "cannot select tasks by labels: %v\n", err)
+			return CodeInvalidArgs
+		}
+	}
 
 	if len(tasks) == 0 {
 		fmt.Fprintln(f.output, "no task provided")
@@ -46,6 +98,65 @@ func (f *flowRunner) Run(ctx context.Context, args []string) int {
 		return CodeInvalidArgs
 	}
 
+	if planParamVal, ok := f.paramValues[f.plan.Name()]; ok && planParamVal.Get().(bool) {
+		return f.printPlan(tasks)
+	}
+
+	if missing := f.missingRequiredParams(); len(missing) > 0 {
+		fmt.Fprintf(f.output, "missing required parameters: %s\n", strings.Join(missing, ", "))
+		return CodeInvalidArgs
+	}
+
+	return f.runSelected(ctx, tasks)
+}
+
+// printRequested prints the usage, the task list or the completion script
+// requested in the arguments.
+func (f *flowRunner) printRequested(parsed parsedArgs) int {
+	if parsed.usageRequested {
+		printUsage(f)
+		return CodePass
+	}
+
+	if parsed.listRequested {
+		for _, name := range sortedTaskNames(f.tasks) {
+			fmt.Fprintln(f.output, name)
+		}
+		return CodePass
+	}
+
+	if err := f.writeCompletionScript(parsed.completion, f.output); err != nil {
+		fmt.Fprintf(f.output, "cannot print completion script: %v\n", err)
+		return CodeInvalidArgs
+	}
+	return CodePass
+}
+
+// runSelected runs the selected tasks and writes the requested trace and reports.
+func (f *flowRunner) runSelected(ctx context.Context, tasks []string) int {
+	if f.traceFile != "" {
+		stopTrace, err := startTrace(f.traceFile)
+		if err != nil {
+			fmt.Fprintf(f.output, "cannot start trace: %v\n", err)
+			return CodeFail
+		}
+		defer func() {
+			if err := stopTrace(); err != nil {
+				fmt.Fprintf(f.output, "cannot write trace: %v\n", err)
+			}
+		}()
+	}
+
+	// the report's path is relative to the working directory in which the taskflow is run
+	reportJUnit := f.reportJUnit
+	if reportJUnit != "" {
+		var err error
+		if reportJUnit, err = filepath.Abs(reportJUnit); err != nil {
+			fmt.Fprintf(f.output, "cannot get JUnit report path: %v\n", err)
+			return CodeFail
+		}
+	}
+
 	popWorkingDir, err := f.pushWorkingDir()
 	if err != nil {
 		fmt.Fprintf(f.output, "cannot change working directory: %v\n", err)
@@ -53,7 +164,29 @@ func (f *flowRunner) Run(ctx context.Context, args []string) int {
 	}
 	defer popWorkingDir()
 
-	return f.runTasks(ctx, tasks)
+	code := f.runTasks(ctx, tasks)
+	if f.printGantt {
+		printGantt(f.infoOutput(), f.stats.Tasks)
+	}
+	if reportJUnit != "" {
+		if err := writeJUnitReportFile(reportJUnit, f.stats); err != nil {
+			fmt.Fprintf(f.output, "cannot write JUnit report: %v\n", err)
+			return CodeFail
+		}
+	}
+	return code
+}
+
+// rerun sets the parameters using the arguments and runs the given tasks
+// instead of the tasks provided in the arguments.
+func (f *flowRunner) rerun(ctx context.Context, args []string, tasks []string) int {
+	parsed, ok := f.setUp(args)
+	if !ok {
+		return CodeInvalidArgs
+	}
+	parsed.tasks = tasks
+	parsed.labels = nil
+	return f.runParsed(ctx, parsed)
 }
 
 func (f *flowRunner) verifyAllParametersAreInUse() {
@@ -64,67 +197,264 @@ func (f *flowRunner) verifyAllParametersAreInUse() {
 
 func (f *flowRunner) initializeParameters() {
 	f.paramValues = make(map[string]ParamValue)
+	f.explicit = make(map[string]bool)
+	f.fromEnv = make(map[string]bool)
 	for _, param := range f.params {
 		value := param.newValue()
 		f.paramValues[param.name] = value
 	}
 }
 
-func (f *flowRunner) parseArguments(args []string) ([]string, bool, error) {
-	usageRequested := false
-	var argHandler func(string) error
-	handleNextArgFor := func(value ParamValue) {
-		nextHandler := argHandler
-		argHandler = func(s string) error {
-			err := value.Set(s)
-			argHandler = nextHandler
-			return err
+// listFlag is the built-in flag which prints the names of all registered tasks.
+const listFlag = "-list"
+
+// parsedArgs contains the result of parsing the command-line arguments.
+type parsedArgs struct {
+	tasks          []string
+	usageRequested bool
+	listRequested  bool
+	completion     string            // shell for which the completion script is requested
+	labels         map[string]string // labels which the tasks to run must have
+}
+
+// setParamValue sets the value of the parameter and validates it.
+func (f *flowRunner) setParamValue(name string, s string) error {
+	f.explicit[name] = true
+	if err := f.paramValues[name].Set(s); err != nil {
+		return err
+	}
+	if validate := f.params[name].validate; validate != nil {
+		if err := validate(s); err != nil {
+			return &ParamError{Key: name, Err: err}
 		}
 	}
-	var tasks []string
+	return nil
+}
 
-	argHandler = func(arg string) error {
-		if _, isTask := f.tasks[arg]; isTask {
-			tasks = append(tasks, arg)
+// setEnvValues sets the values of the parameters from their environment variables.
+func (f *flowRunner) setEnvValues() error {
+	for _, param := range f.params {
+		if param.envVar == "" {
+			continue
+		}
+		s, ok := os.LookupEnv(param.envVar)
+		if !ok {
+			continue
+		}
+		if err := f.setParamValue(param.name, s); err != nil {
+			return fmt.Errorf("invalid %s environment variable: %v", param.envVar, err)
+		}
+		f.fromEnv[param.name] = true
+	}
+	return nil
+}
+
+func (f *flowRunner) parseArguments(args []string) (parsedArgs, error) {
+	p := &argParser{f: f}
+	for _, arg := range args {
+		if err := p.parse(arg); err != nil {
+			return parsedArgs{}, err
+		}
+	}
+	return p.result, nil
+}
+
+// argParser parses the command line arguments one by one.
+type argParser struct {
+	f      *flowRunner
+	result parsedArgs
+	// next handles the argument following a flag which expects a value.
+	// It reports whether the argument was consumed.
+	next func(arg string) (bool, error)
+}
+
+func (p *argParser) parse(arg string) error {
+	if next := p.next; next != nil {
+		p.next = nil
+		if consumed, err := next(arg); consumed {
+			return err
+		}
+	}
+	if _, isTask := p.f.tasks[arg]; isTask {
+		p.result.tasks = append(p.result.tasks, arg)
+		return nil
+	}
+	if arg == "-" {
+		if p.f.literalStdin {
+			p.result.tasks = append(p.result.tasks, arg)
 			return nil
 		}
-		if arg[0] == '-' {
-			// parse parameters
-			split := strings.SplitN(arg[1:], "=", 2) //nolint:gomnd // ignore
-			if value, isFlag := f.paramValues[split[0]]; isFlag {
-				switch {
-				case len(split) > 1:
-					return value.Set(split[1])
-				case value.IsBool():
-					return value.Set("")
-				default:
-					handleNextArgFor(value)
-					return nil
-				}
+		return p.readTasks()
+	}
+	if arg[0] == '-' {
+		if parsed, err := p.parseParam(arg); parsed {
+			return err
+		}
+	}
+	return p.parseBuiltIn(arg)
+}
+
+// readTasks reads whitespace-separated task names from stdin.
+func (p *argParser) readTasks() error {
+	input, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("cannot read tasks from stdin: %v", err)
+	}
+	for _, name := range strings.Fields(string(input)) {
+		if _, isTask := p.f.tasks[name]; !isTask {
+			return fmt.Errorf("unknown task: %s", name)
+		}
+		p.result.tasks = append(p.result.tasks, name)
+	}
+	return nil
+}
+
+// parseParam parses the flag of a registered parameter.
+// It reports whether the argument is such a flag.
+func (p *argParser) parseParam(arg string) (bool, error) {
+	split := strings.SplitN(arg[1:], "=", 2) //nolint:gomnd // ignore
+	if name, isFlag := p.f.paramByFlag(split[0]); isFlag {
+		switch {
+		case len(split) > 1:
+			return true, p.setValue(name, split[0], split[1])
+		case p.f.paramValues[name].IsBool():
+			if name == p.f.verbosity.Name() {
+				p.next = p.levelFor(name, split[0])
 			}
+			return true, p.setValue(name, split[0], "")
+		default:
+			p.next = p.valueFor(name, split[0])
+			return true, nil
 		}
-		// if they haven't been overridden above, provide usage for common queries
-		if (arg == "-h") || (arg == "--help") || (arg == "help") {
-			usageRequested = true
-			return nil
+	}
+	// -no-<name> sets a boolean parameter to false
+	if flag := strings.TrimPrefix(arg[1:], "no-"); flag != arg[1:] {
+		if name, isFlag := p.f.paramByFlag(flag); isFlag && p.f.paramValues[name].IsBool() {
+			return true, p.setValue(name, arg[1:], "false")
 		}
-		return fmt.Errorf("unknown argument: %s", arg)
 	}
+	return false, nil
+}
 
-	for _, arg := range args {
-		err := argHandler(arg)
+// parseBuiltIn parses the built-in flags which haven't been overridden by the parameters.
+func (p *argParser) parseBuiltIn(arg string) error {
+	// provide usage for common queries
+	if (arg == "-h") || (arg == "--help") || (arg == "help") {
+		p.result.usageRequested = true
+		return nil
+	}
+	if arg == listFlag {
+		p.result.listRequested = true
+		return nil
+	}
+	if strings.HasPrefix(arg, labelFlag+"=") {
+		key, value, err := parseLabel(strings.TrimPrefix(arg, labelFlag+"="))
 		if err != nil {
-			return []string{}, false, err
+			return err
+		}
+		if p.result.labels == nil {
+			p.result.labels = map[string]string{}
+		}
+		p.result.labels[key] = value
+		return nil
+	}
+	if strings.HasPrefix(arg, completionFlag+"=") {
+		p.result.completion = strings.TrimPrefix(arg, completionFlag+"=")
+		return nil
+	}
+	return fmt.Errorf("unknown argument: %s", arg)
+}
+
+// valueFor returns the handler which sets the parameter to the next argument.
+// flag is the name or alias of the parameter used in the arguments.
+func (p *argParser) valueFor(name, flag string) func(string) (bool, error) {
+	return func(s string) (bool, error) {
+		return true, p.setValue(name, flag, s)
+	}
+}
+
+// levelFor returns the handler which sets the verbosity level
+// if it is provided as the next argument, e.g. -v 2.
+func (p *argParser) levelFor(name, flag string) func(string) (bool, error) {
+	return func(s string) (bool, error) {
+		if _, isTask := p.f.tasks[s]; isTask || !isVerbosityLevel(s) {
+			return false, nil
 		}
+		return true, p.setValue(name, flag, s)
 	}
-	return tasks, usageRequested, nil
+}
+
+// setValue sets the value of the parameter provided via CLI.
+// flag is the name or alias of the parameter used in the arguments.
+func (p *argParser) setValue(name, flag, s string) error {
+	f := p.f
+	if msg := f.params[name].deprecated; msg != "" {
+		fmt.Fprintf(f.infoOutput(), "warning: flag -%s is deprecated: %s\n", flag, msg)
+	}
+	if f.fromEnv[name] {
+		// the value set via CLI replaces the one from the environment variable,
+		// even for the parameters which accumulate the values, e.g. MultiStringParam
+		f.paramValues[name] = f.params[name].newValue()
+		delete(f.fromEnv, name)
+	}
+	return f.setParamValue(name, s)
+}
+
+// isVerbosityLevel reports whether the argument is a verbosity level, i.e. a non-negative integer.
+func isVerbosityLevel(arg string) bool {
+	_, err := strconv.ParseUint(arg, 10, 0)
+	return err == nil
+}
+
+// paramByFlag returns the name of the parameter which is set by the CLI flag
+// given by its name or one of its aliases.
+func (f *flowRunner) paramByFlag(flag string) (string, bool) {
+	if param, ok := f.params[flag]; ok {
+		return flag, !param.envOnly
+	}
+	for _, param := range f.params {
+		for _, alias := range param.aliases {
+			if alias == flag {
+				return param.name, !param.envOnly
+			}
+		}
+	}
+	return "", false
+}
+
+func (f *flowRunner) missingRequiredParams() []string {
+	var missing []string
+	for _, param := range f.params {
+		if param.required && !f.explicit[param.name] {
+			missing = append(missing, "-"+param.name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
 }
 
 func (f *flowRunner) tasksToRun(tasks []string) []string {
-	if len(tasks) > 0 || (f.defaultTask.name == "") {
+	if len(tasks) > 0 {
 		return tasks
 	}
-	return []string{f.defaultTask.name}
+	for _, task := range f.defaultTasks {
+		tasks = append(tasks, task.name)
+	}
+	return tasks
+}
+
+func (f *flowRunner) printPlan(tasks []string) int {
+	order, err := topologicalOrder(f.tasks, tasks)
+	if err != nil {
+		fmt.Fprintf(f.output, "cannot plan tasks: %v\n", err)
+		return CodeInvalidArgs
+	}
+	depths := map[string]int{}
+	for _, name := range order {
+		indent := strings.Repeat("  ", taskDepth(f.tasks, name, depths))
+		fmt.Fprintf(f.output, "%s%s\n", indent, name)
+	}
+	return CodePass
 }
 
 func (f *flowRunner) pushWorkingDir() (func(), error) {
@@ -152,35 +482,98 @@ func (f *flowRunner) pushWorkingDir() (func(), error) {
 func (f *flowRunner) runTasks(ctx context.Context, tasks []string) int {
 	from := time.Now()
 	executedTasks := map[string]bool{}
+	defer func() {
+		f.stats.Total = time.Since(from)
+	}()
+	var failedTasks []string
+	failed := map[string]bool{}
 	for _, name := range tasks {
-		if err := f.run(ctx, name, executedTasks); err != nil {
-			fmt.Fprintf(f.output, "%v\t%.3fs\n", err, time.Since(from).Seconds())
+		taskErr := f.run(ctx, name, executedTasks)
+		if taskErr == nil {
+			continue
+		}
+		if f.taskErr == nil {
+			f.taskErr = taskErr
+		}
+		if taskErr.Cause != nil {
+			// the run was interrupted, e.g. the context was canceled
+			fmt.Fprintf(f.humanOutput(), "%v\t%.3fs\n", taskErr, time.Since(from).Seconds())
 			return CodeFail
 		}
+		// a task which depends on an already failed task reports the same failure
+		if failed[taskErr.TaskName] {
+			continue
+		}
+		failed[taskErr.TaskName] = true
+		failedTasks = append(failedTasks, taskErr.TaskName)
+		if len(failedTasks) >= f.maxFailures {
+			break
+		}
 	}
-	fmt.Fprintf(f.output, "ok\t%.3fs\n", time.Since(from).Seconds())
-	return CodePass
+	switch len(failedTasks) {
+	case 0:
+		fmt.Fprintf(f.humanOutput(), "ok\t%.3fs\n", time.Since(from).Seconds())
+		return CodePass
+	case 1:
+		fmt.Fprintf(f.humanOutput(), "%v\t%.3fs\n", f.taskErr, time.Since(from).Seconds())
+	default:
+		fmt.Fprintf(f.humanOutput(), "tasks failed: %s\t%.3fs\n", strings.Join(failedTasks, ", "), time.Since(from).Seconds())
+	}
+	return CodeFail
+}
+
+// verbosityLevel returns the value of the verbosity parameter or 0 if it is not set.
+func (f *flowRunner) verbosityLevel() int {
+	if value, ok := f.paramValues[f.verbosity.Name()]; ok {
+		return value.Get().(int)
+	}
+	return 0
 }
 
-func (f *flowRunner) run(ctx context.Context, name string, executed map[string]bool) error {
+// infoOutput returns the output for the messages which are not printed in quiet mode,
+// unless the verbose mode is enabled.
+func (f *flowRunner) infoOutput() io.Writer {
+	if f.quiet && f.verbosityLevel() < 1 {
+		return ioutil.Discard
+	}
+	return f.humanOutput()
+}
+
+// run runs the task and its dependencies.
+// The executed map records the results of the tasks which were already run.
+// The returned error has a Cause if the run was interrupted.
+func (f *flowRunner) run(ctx context.Context, name string, executed map[string]bool) *TaskError {
 	task := f.tasks[name]
-	if executed[name] {
+	if passed, ok := executed[name]; ok && !task.RunAlways {
+		if !passed {
+			return &TaskError{TaskName: name, ExitCode: CodeFail}
+		}
 		return nil
 	}
-	for _, dep := range task.Deps {
-		if err := f.run(ctx, dep.name, executed); err != nil {
+	for _, dep := range orderedDeps(f.tasks, task) {
+		start := time.Now()
+		err := f.run(ctx, dep.name, executed)
+		if f.verbosityLevel() >= 2 { //nolint:gomnd // debug level
+			fmt.Fprintf(f.infoOutput(), "      dependency %s of %s checked (%.2fs)\n", dep.name, name, time.Since(start).Seconds())
+		}
+		if err != nil {
 			return err
 		}
 	}
 	if err := ctx.Err(); err != nil {
-		return err
+		return &TaskError{TaskName: name, ExitCode: CodeFail, Cause: err}
 	}
 	passed := f.runTask(ctx, task)
 	if err := ctx.Err(); err != nil {
-		return err
+		return &TaskError{TaskName: name, ExitCode: CodeFail, Cause: err}
 	}
 	if !passed {
-		return errors.New("task failed")
+		for _, dep := range task.OnFailureDeps {
+			fmt.Fprintf(f.infoOutput(), "===== ON-FAILURE  %s\n", dep.name)
+			f.run(ctx, dep.name, executed) // on-failure tasks do not affect the result
+		}
+		executed[name] = false
+		return &TaskError{TaskName: name, ExitCode: CodeFail}
 	}
 	executed[name] = true
 	return nil
@@ -191,68 +584,206 @@ func (f *flowRunner) runTask(ctx context.Context, task Task) bool {
 		return true
 	}
 
-	paramValues := make(map[string]ParamValue)
-	for _, param := range task.Params {
-		paramValues[param.Name()] = f.paramValues[param.Name()]
-	}
+	paramValues, paramNames := f.taskParamValues(task)
 
-	// if verbose flag is registered then check its value
-	verboseParamVal, ok := f.paramValues[f.verbose.Name()]
-	verbose := ok && verboseParamVal.Get().(bool)
+	action := taskAction(task)
+	if f.useCache && task.Cacheable {
+		action = cachedAction(task, action)
+	}
 
 	failed := false
 	measuredAction := func(tf *TF) {
-		w := tf.Output()
-		if !verbose {
-			w = &strings.Builder{}
-		}
-
-		// report task start
-		fmt.Fprintf(w, "===== TASK  %s\n", tf.Name())
-
-		// run task
-		r := runner{
-			Ctx:         tf.Context(),
-			TaskName:    tf.Name(),
-			ParamValues: tf.paramValues,
-			Output:      w,
-		}
-		result := r.Run(task.Action)
-
-		// report task end
-		status := "PASS"
-		switch {
-		case result.Failed():
-			status = "FAIL"
-			failed = true
-		case result.Skipped():
-			status = "SKIP"
-		}
-		fmt.Fprintf(w, "----- %s: %s (%.2fs)\n", status, tf.Name(), result.Duration().Seconds())
-
-		if sb, ok := w.(*strings.Builder); ok && result.failed {
-			io.Copy(tf.Output(), strings.NewReader(sb.String())) //nolint // not checking errors when writing to output
-		}
+		w, finish := f.taskOutput(tf)
+		f.reportTaskStart(tf, w, paramNames)
+		start := time.Now()
+		result := f.runAction(tf, w, action)
+		end := time.Now()
+		f.reportTaskEnd(tf, w, result, start, end)
+		failed = result.Failed()
+		finish(failed)
 	}
 
+	ctx, traceTask := trace.NewTask(ctx, "task")
+	defer traceTask.End()
+	trace.Log(ctx, "start", task.Name)
+	defer trace.Log(ctx, "end", task.Name)
+
 	measuredRunner := runner{
 		Ctx:         ctx,
 		TaskName:    task.Name,
 		ParamValues: paramValues,
-		Output:      f.output,
+		Output:      f.humanOutput(),
 	}
 	measuredRunner.Run(measuredAction)
 
 	return !failed
 }
 
+// taskParamValues returns the values of the parameters which the task's action can access,
+// i.e. the task's parameters and the verbosity parameter,
+// and the sorted names of the task's parameters.
+func (f *flowRunner) taskParamValues(task Task) (map[string]ParamValue, []string) {
+	paramValues := make(map[string]ParamValue)
+	if value, ok := f.paramValues[f.verbosity.Name()]; ok {
+		paramValues[f.verbosity.Name()] = value
+	}
+	paramNames := make([]string, 0, len(task.Params))
+	for _, param := range task.Params {
+		paramValues[param.Name()] = f.paramValues[param.Name()]
+		paramNames = append(paramNames, param.Name())
+	}
+	sort.Strings(paramNames)
+	return paramValues, paramNames
+}
+
+// taskOutput returns the writer for the task's output
+// and the function which must be called when the task finishes.
+// Unless the verbose mode is enabled, the output is printed only if the task fails
+// or, if streamAfter is set, runs longer than streamAfter.
+func (f *flowRunner) taskOutput(tf *TF) (io.Writer, func(failed bool)) {
+	switch {
+	case f.verbosityLevel() >= 1:
+		return tf.Output(), func(bool) {}
+	case f.streamAfter > 0:
+		stream := &streamingWriter{out: tf.Output()}
+		if !f.prefixOutput {
+			stream.prefix = "[" + tf.Name() + "] "
+		}
+		timer := time.AfterFunc(f.streamAfter, stream.stream)
+		return stream, func(failed bool) {
+			timer.Stop()
+			stream.close(failed)
+		}
+	default:
+		buf := &strings.Builder{}
+		return &syncWriter{Writer: buf}, func(failed bool) {
+			if failed {
+				io.Copy(tf.Output(), strings.NewReader(buf.String())) //nolint // not checking errors when writing to output
+			}
+		}
+	}
+}
+
+// reportTaskStart notifies about the start of the task and prints its header to w.
+func (f *flowRunner) reportTaskStart(tf *TF, w io.Writer, paramNames []string) {
+	if f.onTaskStart != nil {
+		f.onTaskStart(tf.Name())
+	}
+	f.notifyTaskStarted(tf.Name())
+	if f.jsonLines {
+		f.writeJSONStart(tf.Name())
+	}
+	fmt.Fprintf(w, "===== TASK  %s\n", tf.Name())
+	if f.verbosityLevel() >= 2 { //nolint:gomnd // debug level
+		for _, name := range paramNames {
+			fmt.Fprintf(w, "      -%s=%s\n", name, f.paramValues[name].String())
+		}
+	}
+}
+
+// runAction runs the task's action writing its output to w.
+func (f *flowRunner) runAction(tf *TF, w io.Writer, action func(tf *TF)) runResult {
+	var actionOutput io.Writer = w
+	var lines *lineWriter
+	switch {
+	case f.jsonLines:
+		lines = f.newJSONLogWriter(tf.Name())
+	case f.prefixOutput:
+		lines = newPrefixWriter(w, "["+tf.Name()+"] ")
+	}
+	if lines != nil {
+		actionOutput = lines
+	}
+	// in quiet mode the progress is printed only if the task fails
+	progressOutput := tf.Output()
+	if f.quiet {
+		progressOutput = w
+	}
+	r := runner{
+		Ctx:         tf.Context(),
+		TaskName:    tf.Name(),
+		ParamValues: tf.paramValues,
+		Output:      actionOutput,
+		Store:       f.store,
+		Progress:    progressOutput,
+	}
+	result := r.Run(action)
+	if lines != nil {
+		lines.Flush() //nolint // not checking errors when writing to output
+	}
+	return result
+}
+
+// reportTaskEnd prints the result of the task to w, records it and notifies about it.
+func (f *flowRunner) reportTaskEnd(tf *TF, w io.Writer, result runResult, start, end time.Time) {
+	status := result.Status()
+	statusText := status
+	if f.color {
+		statusText = colorStatus(status)
+	}
+	fmt.Fprintf(w, "----- %s: %s (%.2fs)\n", statusText, tf.Name(), result.Duration().Seconds())
+	taskResult := TaskResult{Name: tf.Name(), Status: status, Duration: result.Duration(), Start: start, End: end}
+	f.statsMtx.Lock()
+	f.stats.Tasks = append(f.stats.Tasks, taskResult)
+	f.statsMtx.Unlock()
+	if f.onTaskEnd != nil {
+		f.onTaskEnd(tf.Name(), taskResult)
+	}
+	f.notifyTaskEnded(taskResult)
+	if f.jsonLines {
+		f.writeJSONEnd(taskResult)
+	}
+}
+
+// taskAction returns the function which is run for the task.
+// It wraps the task's action with the additional behavior defined by the task's fields.
+func taskAction(task Task) func(tf *TF) {
+	action := task.Action
+	if task.SkipIf != nil {
+		next := action
+		action = func(tf *TF) {
+			if task.SkipIf(tf.Context()) {
+				tf.SkipNow()
+			}
+			next(tf)
+		}
+	}
+	if task.Condition != nil {
+		next := action
+		action = func(tf *TF) {
+			if !task.Condition(tf) {
+				tf.SkipNow()
+			}
+			next(tf)
+		}
+	}
+	if task.WorkDir != "" {
+		next := action
+		action = func(tf *TF) {
+			tf.Chdir(task.WorkDir)
+			next(tf)
+		}
+	}
+	if len(task.Environment) > 0 {
+		next := action
+		action = func(tf *TF) {
+			for key, value := range task.Environment {
+				tf.Setenv(key, value)
+			}
+			next(tf)
+		}
+	}
+	return action
+}
+
 func (f *flowRunner) unusedParams() []string {
 	remainingParams := make(map[string]struct{})
 	for key := range f.params {
 		remainingParams[key] = struct{}{}
 	}
-	delete(remainingParams, f.verbose.Name())
+	delete(remainingParams, f.verbosity.Name())
 	delete(remainingParams, f.workDir.Name())
+	delete(remainingParams, f.plan.Name())
 	for _, task := range f.tasks {
 		for _, param := range task.Params {
 			delete(remainingParams, param.Name())
@@ -266,13 +797,35 @@ func (f *flowRunner) unusedParams() []string {
 }
 
 func printUsage(f *flowRunner) {
-	flagName := func(paramName string) string {
-		return "-" + paramName
-	}
-
 	fmt.Fprintf(f.output, "Usage: [flag(s) | task(s)]...\n")
 	fmt.Fprintf(f.output, "Flags:\n")
 	w := tabwriter.NewWriter(f.output, 1, 1, 4, ' ', 0) //nolint:gomnd // ignore
+	printFlagsUsage(f, w)
+	w.Flush() //nolint // not checking errors when writing to output
+
+	fmt.Fprintf(f.output, "Tasks:\n")
+	printTasksUsage(f, w)
+	w.Flush() //nolint // not checking errors when writing to output
+
+	switch len(f.defaultTasks) {
+	case 0:
+	case 1:
+		fmt.Fprintf(f.output, "Default task: %s\n", f.defaultTasks[0].name)
+	default:
+		names := make([]string, len(f.defaultTasks))
+		for i, task := range f.defaultTasks {
+			names[i] = task.name
+		}
+		fmt.Fprintf(f.output, "Default tasks: %s\n", strings.Join(names, ", "))
+	}
+}
+
+func flagName(paramName string) string {
+	return "-" + paramName
+}
+
+// printFlagsUsage prints the usage of the parameters which can be set via CLI.
+func printFlagsUsage(f *flowRunner, w io.Writer) {
 	keys := make([]string, 0, len(f.params))
 	for key := range f.params {
 		keys = append(keys, key)
@@ -280,35 +833,78 @@ func printUsage(f *flowRunner) {
 	sort.Strings(keys)
 	for _, key := range keys {
 		param := f.params[key]
-		fmt.Fprintf(w, "  %s\tDefault: %s\t%s\n", flagName(param.name), param.newValue().String(), param.usage)
+		if param.envOnly {
+			continue
+		}
+		defaultText := param.newValue().String()
+		if param.hint != "" {
+			defaultText += " (" + param.hint + ")"
+		}
+		flags := flagName(param.name)
+		for _, alias := range param.aliases {
+			flags += ", " + flagName(alias)
+		}
+		fmt.Fprintf(w, "  %s\tDefault: %s\t%s\n", flags, defaultText, param.usage)
 	}
-	w.Flush() //nolint // not checking errors when writing to output
+}
 
-	fmt.Fprintf(f.output, "Tasks:\n")
-	keys = make([]string, 0, len(f.tasks))
+// printTasksUsage prints the usage of the tasks which have it.
+func printTasksUsage(f *flowRunner, w io.Writer) {
+	keys := make([]string, 0, len(f.tasks))
 	for k, task := range f.tasks {
 		if task.Usage == "" {
 			continue
 		}
 		keys = append(keys, k)
 	}
-	sort.Strings(keys)
+	// list the tasks without a group first and then the tasks of each group
+	sort.Slice(keys, func(i, j int) bool {
+		gi, gj := taskGroupName(keys[i]), taskGroupName(keys[j])
+		if gi != gj {
+			return gi < gj
+		}
+		return keys[i] < keys[j]
+	})
 	for _, k := range keys {
 		t := f.tasks[k]
 		params := make([]string, len(t.Params))
 		for i, param := range t.Params {
 			params[i] = flagName(param.Name())
+			if p := f.params[param.Name()]; p.envOnly {
+				params[i] = "$" + p.envVar
+			}
 		}
 		sort.Strings(params)
 		paramsText := ""
 		if len(params) > 0 {
 			paramsText = "; " + strings.Join(params, " ")
 		}
-		fmt.Fprintf(w, "  %s\t%s%s\n", t.Name, t.Usage, paramsText)
+		lines := wrapText(t.Usage+paramsText, f.usageWidth)
+		fmt.Fprintf(w, "  %s\t%s\n", t.Name, lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(w, "  \t%s\n", line)
+		}
 	}
-	w.Flush() //nolint // not checking errors when writing to output
+}
 
-	if f.defaultTask.name != "" {
-		fmt.Fprintf(f.output, "Default task: %s\n", f.defaultTask.name)
+// wrapText splits the text into lines which are at most width characters long,
+// unless a single word is longer. Newlines in the text are preserved.
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case len(line)+1+len(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
 	}
+	return lines
 }
@@ -0,0 +1,59 @@
+package goyek
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// ganttWidth is the number of time buckets in the Gantt chart.
+const ganttWidth = 50
+
+// printGantt prints a text Gantt chart of the tasks' results.
+// Each row is a task and each column is a time bucket
+// which contains '#' if the task was running and '.' otherwise.
+func printGantt(w io.Writer, results []TaskResult) {
+	if len(results) == 0 {
+		return
+	}
+	origin, end := results[0].Start, results[0].End
+	for _, result := range results {
+		if result.Start.Before(origin) {
+			origin = result.Start
+		}
+		if result.End.After(end) {
+			end = result.End
+		}
+	}
+	bucket := end.Sub(origin) / ganttWidth
+	if bucket <= 0 {
+		bucket = 1
+	}
+
+	fmt.Fprintf(w, "Gantt chart (1 column = %v):\n", bucket)
+	tw := tabwriter.NewWriter(w, 1, 1, 1, ' ', 0) //nolint:gomnd // ignore
+	for _, result := range results {
+		first := int(result.Start.Sub(origin) / bucket)
+		last := int((result.End.Sub(origin) - 1) / bucket)
+		if first >= ganttWidth {
+			first = ganttWidth - 1
+		}
+		if last >= ganttWidth {
+			last = ganttWidth - 1
+		}
+		if last < first {
+			last = first
+		}
+		sb := &strings.Builder{}
+		for i := 0; i < ganttWidth; i++ {
+			if i >= first && i <= last {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		fmt.Fprintf(tw, "  %s\t|%s|\t%.3fs\n", result.Name, sb.String(), result.Duration.Seconds())
+	}
+	tw.Flush() //nolint // not checking errors when writing to output
+}
@@ -0,0 +1,37 @@
+//go:build go1.13
+// +build go1.13
+
+// Package goyektest contains helpers for testing and benchmarking taskflows.
+// It is a separate package so that the testing package is not linked into build programs.
+package goyektest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek"
+)
+
+// Benchmark runs the action of the task with the given name b.N times
+// and reports the average duration of the action as ns/op.
+// Each run uses a fresh TF with the default values of the task's parameters.
+// Neither the task's dependencies nor the creation of the parameter values are measured.
+//
+// The results are meaningful only if the action does not have side effects
+// which affect the subsequent runs.
+func Benchmark(ctx context.Context, flow *goyek.Taskflow, taskName string, b *testing.B) {
+	var total time.Duration
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := flow.RunAction(ctx, taskName)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if result.Status == "FAIL" {
+			b.Fatalf("task failed: %s", taskName)
+		}
+		total += result.Duration
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "ns/op")
+}
@@ -0,0 +1,39 @@
+//go:build go1.13
+// +build go1.13
+
+package goyektest_test
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/goyek/goyek"
+	"github.com/goyek/goyek/goyektest"
+)
+
+func Test_Benchmark(t *testing.T) {
+	// run a fixed number of iterations instead of the default 1s
+	benchtime := flag.Lookup("test.benchtime")
+	defer benchtime.Value.Set(benchtime.Value.String()) //nolint:errcheck // restores the previous value
+	if err := benchtime.Value.Set("10x"); err != nil {
+		t.Fatal(err)
+	}
+	flow := &goyek.Taskflow{}
+	runs := 0
+	flow.Register(goyek.Task{
+		Name:   "task",
+		Action: func(tf *goyek.TF) { runs++ },
+	})
+
+	result := testing.Benchmark(func(b *testing.B) {
+		goyektest.Benchmark(context.Background(), flow, "task", b)
+	})
+
+	if result.N != 10 || runs < result.N {
+		t.Errorf("should run the action b.N times, got N: %d, runs: %d", result.N, runs)
+	}
+	if _, ok := result.Extra["ns/op"]; !ok {
+		t.Error("should report ns/op")
+	}
+}
@@ -0,0 +1,169 @@
+package goyek
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DotGraph writes the tasks' dependency graph in the Graphviz DOT format.
+// Each node is a task and each edge points from a task to its dependency.
+// Tasks with the same dependency depth are placed on the same rank.
+// Tasks without an action are rendered as boxes.
+func (f *Taskflow) DotGraph(w io.Writer) error {
+	names := sortedTaskNames(f.tasks)
+	depths := map[string]int{}
+	maxDepth := 0
+	for _, name := range names {
+		if d := taskDepth(f.tasks, name, depths); d > maxDepth {
+			maxDepth = d
+		}
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString("digraph goyek {\n")
+	for depth := 0; depth <= maxDepth && len(names) > 0; depth++ {
+		sb.WriteString("\t{\n\t\trank=same;\n")
+		for _, name := range names {
+			if depths[name] == depth {
+				fmt.Fprintf(sb, "\t\t%q;\n", name)
+			}
+		}
+		sb.WriteString("\t}\n")
+	}
+	for _, name := range names {
+		if f.tasks[name].Action == nil {
+			fmt.Fprintf(sb, "\t%q [shape=box];\n", name)
+		}
+	}
+	for _, name := range names {
+		for _, dep := range f.tasks[name].Deps {
+			fmt.Fprintf(sb, "\t%q -> %q;\n", name, dep.name)
+		}
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// PrintDeps writes the dependency tree of the task, indenting each dependency
+// by two spaces more than its dependent task.
+// A task which is already listed is marked with "[cached]" and its dependencies are not repeated.
+// An error is returned if the task is not registered.
+func (f *Taskflow) PrintDeps(taskName string, w io.Writer) error {
+	if _, ok := f.tasks[taskName]; !ok {
+		return fmt.Errorf("unknown task: %s", taskName)
+	}
+
+	sb := &strings.Builder{}
+	listed := map[string]bool{}
+	var printTask func(name string, depth int)
+	printTask = func(name string, depth int) {
+		indent := strings.Repeat("  ", depth)
+		if listed[name] {
+			fmt.Fprintf(sb, "%s%s [cached]\n", indent, name)
+			return
+		}
+		listed[name] = true
+		fmt.Fprintf(sb, "%s%s\n", indent, name)
+		for _, dep := range orderedDeps(f.tasks, f.tasks[name]) {
+			printTask(dep.name, depth+1)
+		}
+	}
+	printTask(taskName, 0)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// TopologicalOrder returns the names of the provided tasks and all their dependencies
+// in the order in which they would be executed by Run.
+// Each task is listed at most once unless its RunAlways field is set,
+// in which case it is listed each time it would be executed.
+// An error is returned if any of the tasks is not registered
+// or if the dependency graph contains a cycle.
+func (f *Taskflow) TopologicalOrder(taskNames ...string) ([]string, error) {
+	return topologicalOrder(f.tasks, taskNames)
+}
+
+func topologicalOrder(tasks map[string]Task, taskNames []string) ([]string, error) {
+	var order []string
+	visited := map[string]bool{}
+	var path []string // the tasks being visited, from the outermost one
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] && !tasks[name].RunAlways {
+			return nil
+		}
+		for i, visiting := range path {
+			if visiting == name {
+				cycle := append(append([]string(nil), path[i:]...), name)
+				return fmt.Errorf("circular dependency: %s", strings.Join(cycle, " → "))
+			}
+		}
+		task, ok := tasks[name]
+		if !ok {
+			return fmt.Errorf("unknown task: %s", name)
+		}
+		path = append(path, name)
+		for _, dep := range orderedDeps(tasks, task) {
+			if err := visit(dep.name); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		visited[name] = true
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range taskNames {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// orderedDeps returns the dependencies of the task in the order in which they are run.
+func orderedDeps(tasks map[string]Task, task Task) Deps {
+	var less func(a, b RegisteredTask) bool
+	switch task.DependencyOrder {
+	case DependencyOrderAlpha:
+		less = func(a, b RegisteredTask) bool { return a.name < b.name }
+	case DependencyOrderRegistration:
+		less = func(a, b RegisteredTask) bool { return tasks[a.name].index < tasks[b.name].index }
+	default:
+		return task.Deps
+	}
+	deps := append(Deps(nil), task.Deps...)
+	sort.SliceStable(deps, func(i, j int) bool { return less(deps[i], deps[j]) })
+	return deps
+}
+
+// taskDepth returns the length of the longest dependency chain of the task.
+// The depths map is used to memoize the results.
+func taskDepth(tasks map[string]Task, name string, depths map[string]int) int {
+	if d, ok := depths[name]; ok {
+		return d
+	}
+	d := 0
+	for _, dep := range tasks[name].Deps {
+		if depDepth := taskDepth(tasks, dep.name, depths) + 1; depDepth > d {
+			d = depDepth
+		}
+	}
+	depths[name] = d
+	return d
+}
+
+// sortedTaskNames returns the names of the tasks in alphabetical order.
+func sortedTaskNames(tasks map[string]Task) []string {
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,21 @@
+package goyek
+
+import "testing"
+
+func Test_topologicalOrder_cycle(t *testing.T) {
+	// a cycle cannot be registered using the public API
+	tasks := map[string]Task{
+		"a": {Name: "a", Deps: Deps{{name: "b"}}},
+		"b": {Name: "b", Deps: Deps{{name: "c"}}},
+		"c": {Name: "c", Deps: Deps{{name: "a"}}},
+	}
+
+	order, err := topologicalOrder(tasks, []string{"a"})
+
+	if order != nil {
+		t.Errorf("should not return the order, got: %v", order)
+	}
+	if err == nil || err.Error() != "circular dependency: a → b → c → a" {
+		t.Errorf("should return the cycle, got: %v", err)
+	}
+}
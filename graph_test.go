@@ -0,0 +1,122 @@
+package goyek_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_DotGraph(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	task1 := flow.Register(goyek.Task{Name: "task-1", Action: func(tf *goyek.TF) {}})
+	task2 := flow.Register(goyek.Task{Name: "task-2", Action: func(tf *goyek.TF) {}, Deps: goyek.Deps{task1}})
+	flow.Register(goyek.Task{Name: "all", Deps: goyek.Deps{task1, task2}})
+	sb := &strings.Builder{}
+
+	err := flow.DotGraph(sb)
+
+	requireEqual(t, err, nil, "should not return an error")
+	assertEqual(t, sb.String(), `digraph goyek {
+	{
+		rank=same;
+		"task-1";
+	}
+	{
+		rank=same;
+		"task-2";
+	}
+	{
+		rank=same;
+		"all";
+	}
+	"all" [shape=box];
+	"all" -> "task-1";
+	"all" -> "task-2";
+	"task-2" -> "task-1";
+}
+`, "should write the DOT graph")
+}
+
+func Test_PrintDeps(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	task1 := flow.Register(goyek.Task{Name: "task-1"})
+	task2 := flow.Register(goyek.Task{Name: "task-2", Deps: goyek.Deps{task1}})
+	task4 := flow.Register(goyek.Task{Name: "task-4", Deps: goyek.Deps{task1}})
+	flow.Register(goyek.Task{Name: "task-3", Deps: goyek.Deps{task2, task4}})
+	sb := &strings.Builder{}
+
+	err := flow.PrintDeps("task-3", sb)
+
+	requireEqual(t, err, nil, "should not return an error")
+	assertEqual(t, sb.String(), "task-3\n  task-2\n    task-1\n  task-4\n    task-1 [cached]\n", "should print the dependency tree")
+}
+
+func Test_PrintDeps_unknown_task(t *testing.T) {
+	flow := &goyek.Taskflow{}
+
+	err := flow.PrintDeps("bad-task", &strings.Builder{})
+
+	assertEqual(t, err.Error(), "unknown task: bad-task", "should return an error")
+}
+
+func Test_TopologicalOrder(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	task1 := flow.Register(goyek.Task{Name: "task-1"})
+	task2 := flow.Register(goyek.Task{Name: "task-2", Deps: goyek.Deps{task1}})
+	task3 := flow.Register(goyek.Task{Name: "task-3"})
+	flow.Register(goyek.Task{Name: "all", Deps: goyek.Deps{task2, task3, task1}})
+
+	got, err := flow.TopologicalOrder("task-3", "all")
+
+	requireEqual(t, err, nil, "should not return an error")
+	assertEqual(t, got, []string{"task-3", "task-1", "task-2", "all"}, "should return the execution order")
+}
+
+func Test_TopologicalOrder_run_always(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	notify := flow.Register(goyek.Task{Name: "notify", RunAlways: true})
+	a := flow.Register(goyek.Task{Name: "a", Deps: goyek.Deps{notify}})
+	flow.Register(goyek.Task{Name: "b", Deps: goyek.Deps{notify, a}})
+
+	got, err := flow.TopologicalOrder("b")
+
+	requireEqual(t, err, nil, "should not return an error")
+	assertEqual(t, got, []string{"notify", "notify", "a", "b"}, "should list the RunAlways task each time it is executed")
+}
+
+func Test_TopologicalOrder_dependency_order(t *testing.T) {
+	testCases := []struct {
+		order string
+		want  []string
+	}{
+		{order: "", want: []string{"c", "a", "b", "all"}},
+		{order: goyek.DependencyOrderStable, want: []string{"c", "a", "b", "all"}},
+		{order: goyek.DependencyOrderAlpha, want: []string{"a", "b", "c", "all"}},
+		{order: goyek.DependencyOrderRegistration, want: []string{"b", "c", "a", "all"}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run("order "+tc.order, func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			b := flow.Register(goyek.Task{Name: "b"})
+			c := flow.Register(goyek.Task{Name: "c"})
+			a := flow.Register(goyek.Task{Name: "a"})
+			flow.Register(goyek.Task{Name: "all", Deps: goyek.Deps{c, a, b}, DependencyOrder: tc.order})
+
+			got, err := flow.TopologicalOrder("all")
+
+			requireEqual(t, err, nil, "should not return an error")
+			assertEqual(t, got, tc.want, "should order the dependencies")
+		})
+	}
+}
+
+func Test_TopologicalOrder_unknown_task(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	flow.Register(goyek.Task{Name: "task"})
+
+	_, err := flow.TopologicalOrder("task", "bad-task")
+
+	assertEqual(t, err.Error(), "unknown task: bad-task", "should return an error")
+}
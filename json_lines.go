@@ -0,0 +1,57 @@
+package goyek
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// jsonEvent is a task event printed in the JSON Lines output mode.
+type jsonEvent struct {
+	Event      string `json:"event"` // "start", "log" or "end"
+	Task       string `json:"task"`
+	Time       string `json:"time,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Status     string `json:"status,omitempty"`
+	DurationMs *int64 `json:"durationMs,omitempty"`
+}
+
+// humanOutput returns the writer for the human-readable output of the run.
+func (f *flowRunner) humanOutput() io.Writer {
+	if f.jsonLines {
+		return ioutil.Discard
+	}
+	return f.output
+}
+
+// writeJSONEvent writes the event as a single line of JSON.
+func (f *flowRunner) writeJSONEvent(event jsonEvent) {
+	b, err := json.Marshal(event)
+	if err != nil {
+		panic(err)
+	}
+	f.jsonMtx.Lock()
+	defer f.jsonMtx.Unlock()
+	f.output.Write(append(b, '\n')) //nolint // not checking errors when writing to output
+}
+
+func (f *flowRunner) writeJSONStart(task string) {
+	f.writeJSONEvent(jsonEvent{Event: "start", Task: task, Time: time.Now().Format(time.RFC3339)})
+}
+
+func (f *flowRunner) writeJSONEnd(result TaskResult) {
+	durationMs := int64(result.Duration / time.Millisecond)
+	f.writeJSONEvent(jsonEvent{Event: "end", Task: result.Name, Status: result.Status, DurationMs: &durationMs})
+}
+
+// newJSONLogWriter returns a writer which writes each line as a log event of the task.
+func (f *flowRunner) newJSONLogWriter(task string) *lineWriter {
+	return &lineWriter{
+		writeLine: func(line []byte) error {
+			f.writeJSONEvent(jsonEvent{Event: "log", Task: task, Message: strings.TrimSuffix(string(line), "\n")})
+			return nil
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package goyek
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"strconv"
+)
+
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeJUnitReport writes the run statistics as a JUnit XML test suite
+// in which each executed task is a test case.
+func writeJUnitReport(w io.Writer, stats RunStats) error {
+	suite := junitTestSuite{
+		Name:  "goyek",
+		Tests: len(stats.Tasks),
+		Time:  junitTime(stats.Total.Seconds()),
+	}
+	for _, result := range stats.Tasks {
+		testCase := junitTestCase{
+			Name:      result.Name,
+			Classname: "goyek",
+			Time:      junitTime(result.Duration.Seconds()),
+		}
+		switch result.Status {
+		case "FAIL":
+			suite.Failures++
+			testCase.Failure = &junitMessage{Message: "task failed"}
+		case "SKIP":
+			suite.Skipped++
+			testCase.Skipped = &junitMessage{Message: "task skipped"}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// writeJUnitReportFile writes the JUnit XML report to the file.
+func writeJUnitReportFile(path string, stats RunStats) error {
+	file, err := os.Create(path) //nolint:gosec // the path is provided by the taskflow's author
+	if err != nil {
+		return err
+	}
+	if err := writeJUnitReport(file, stats); err != nil {
+		file.Close() //nolint // the write error is more important
+		return err
+	}
+	return file.Close()
+}
+
+func junitTime(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64) //nolint:gomnd // milliseconds precision
+}
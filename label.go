@@ -0,0 +1,86 @@
+package goyek
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// labelFlag is the built-in flag which restricts the tasks to run to the ones having the label.
+const labelFlag = "-label"
+
+// RunByLabel runs all tasks which have all the given labels and their dependencies.
+// Each task is executed at most once. The parameters have their default values.
+// It returns the same exit codes as Run.
+func (f *Taskflow) RunByLabel(ctx context.Context, labels map[string]string) int {
+	if f.parent != nil {
+		return f.parent.RunByLabel(ctx, labels)
+	}
+	code := f.runWith(ctx, func(ctx context.Context, flow *flowRunner) int {
+		parsed, ok := flow.setUp(nil)
+		if !ok {
+			return CodeInvalidArgs
+		}
+		parsed.labels = map[string]string{}
+		for key, value := range labels {
+			parsed.labels[key] = value
+		}
+		return flow.runParsed(ctx, parsed)
+	})
+	f.lastArgs = nil // no parameter is set via CLI
+	return code
+}
+
+// parseLabel parses the label in the key=value format.
+func parseLabel(s string) (key string, value string, err error) {
+	split := strings.SplitN(s, "=", 2)     //nolint:gomnd // ignore
+	if len(split) != 2 || split[0] == "" { //nolint:gomnd // ignore
+		return "", "", fmt.Errorf("invalid label %q: must be in key=value format", s)
+	}
+	return split[0], split[1], nil
+}
+
+// hasLabels reports whether the task's labels contain all the given labels.
+func (task Task) hasLabels(labels map[string]string) bool {
+	for key, value := range labels {
+		if v, ok := task.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// tasksWithLabels returns the names of the tasks which have all the given labels
+// in the order in which they would be executed.
+// If names is empty, all registered tasks are considered.
+// Otherwise, an error is returned if any of the named tasks does not have the labels.
+func (f *flowRunner) tasksWithLabels(names []string, labels map[string]string) ([]string, error) {
+	named := len(names) > 0
+	if !named {
+		names = sortedTaskNames(f.tasks)
+	}
+	selected := map[string]bool{}
+	for _, name := range names {
+		task, ok := f.tasks[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown task: %s", name)
+		}
+		if task.hasLabels(labels) {
+			selected[name] = true
+		} else if named {
+			return nil, fmt.Errorf("%s task does not have the labels", name)
+		}
+	}
+	order, err := topologicalOrder(f.tasks, names)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, name := range order {
+		if selected[name] {
+			result = append(result, name)
+			delete(selected, name) // a RunAlways task can be listed more than once
+		}
+	}
+	return result, nil
+}
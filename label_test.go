@@ -0,0 +1,111 @@
+package goyek_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_label_flag(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		exitCode int
+		executed []string
+	}{
+		{args: []string{"-label=ci=true"}, exitCode: goyek.CodePass, executed: []string{"lint", "build", "test"}},
+		{args: []string{"-label=ci=true", "-label=team=backend"}, exitCode: goyek.CodePass, executed: []string{"build", "test"}},
+		{args: []string{"-label=ci=true", "test", "lint"}, exitCode: goyek.CodePass, executed: []string{"build", "test", "lint"}},
+		{args: []string{"-label=team=frontend"}, exitCode: goyek.CodePass, executed: []string{"build", "deploy"}},
+
+		{args: []string{"-label=ci=false"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-label=ci"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-label=ci=true", "lint", "deploy"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{Output: &strings.Builder{}}
+			var executed []string
+			action := func(tf *goyek.TF) { executed = append(executed, tf.Name()) }
+			build := flow.Register(goyek.Task{
+				Name:   "build",
+				Action: action,
+			})
+			flow.Register(goyek.Task{
+				Name:   "test",
+				Action: action,
+				Deps:   goyek.Deps{build},
+				Labels: map[string]string{"ci": "true", "team": "backend"},
+			})
+			flow.Register(goyek.Task{
+				Name:   "lint",
+				Action: action,
+				Labels: map[string]string{"ci": "true"},
+			})
+			flow.Register(goyek.Task{
+				Name:   "deploy",
+				Action: action,
+				Deps:   goyek.Deps{build},
+				Labels: map[string]string{"team": "frontend"},
+			})
+
+			exitCode := flow.Run(context.Background(), tc.args...)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, executed, tc.executed, "should run the tasks having the labels and their dependencies")
+		})
+	}
+}
+
+func Test_RunByLabel(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	var executed []string
+	action := func(tf *goyek.TF) { executed = append(executed, tf.Name()) }
+	build := flow.Register(goyek.Task{
+		Name:   "build",
+		Action: action,
+	})
+	flow.Register(goyek.Task{
+		Name:   "test",
+		Action: action,
+		Deps:   goyek.Deps{build},
+		Labels: map[string]string{"ci": "true", "team": "backend"},
+	})
+	flow.Register(goyek.Task{
+		Name:   "lint",
+		Action: action,
+		Labels: map[string]string{"ci": "true"},
+	})
+
+	exitCode := flow.RunByLabel(context.Background(), map[string]string{"team": "backend"})
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, executed, []string{"build", "test"}, "should run the tasks having the labels and their dependencies")
+}
+
+func Test_RunByLabel_RerunFailed(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	param := flow.RegisterStringParam(goyek.StringParam{Name: "mode", Default: "default"})
+	var got []string
+	flow.Register(goyek.Task{
+		Name:   "check",
+		Params: goyek.Params{param},
+		Labels: map[string]string{"ci": "true"},
+		Action: func(tf *goyek.TF) {
+			got = append(got, param.Get(tf))
+			tf.Fail()
+		},
+	})
+
+	flow.Run(context.Background(), "check", "-mode=cli")
+	flow.RunByLabel(context.Background(), map[string]string{"ci": "true"})
+	exitCode := flow.RerunFailed(context.Background())
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should rerun the failed task")
+	assertEqual(t, got, []string{"cli", "default", "default"}, "should rerun with the parameters of the most recent run")
+}
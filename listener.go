@@ -0,0 +1,62 @@
+package goyek
+
+import "time"
+
+// ExecutionListener is notified about the execution of the tasks' actions.
+// Use Taskflow.AddListener to register it.
+type ExecutionListener interface {
+	TaskStarted(name string)
+	TaskPassed(name string, d time.Duration)
+	TaskFailed(name string, d time.Duration)
+	TaskSkipped(name string, d time.Duration)
+}
+
+// NoopListener is an ExecutionListener which does nothing.
+// It can be embedded to implement only some of the methods.
+type NoopListener struct{}
+
+var _ ExecutionListener = NoopListener{}
+
+// TaskStarted does nothing.
+func (NoopListener) TaskStarted(name string) {}
+
+// TaskPassed does nothing.
+func (NoopListener) TaskPassed(name string, d time.Duration) {}
+
+// TaskFailed does nothing.
+func (NoopListener) TaskFailed(name string, d time.Duration) {}
+
+// TaskSkipped does nothing.
+func (NoopListener) TaskSkipped(name string, d time.Duration) {}
+
+// AddListener registers the listener which is notified
+// when a task's action is started and finished.
+// The listeners are notified in the order in which they were added.
+func (f *Taskflow) AddListener(listener ExecutionListener) {
+	if f.parent != nil {
+		f.parent.AddListener(listener)
+		return
+	}
+	f.listeners = append(f.listeners, listener)
+}
+
+// notifyTaskStarted notifies the listeners that the task's action was started.
+func (f *flowRunner) notifyTaskStarted(name string) {
+	for _, listener := range f.listeners {
+		listener.TaskStarted(name)
+	}
+}
+
+// notifyTaskEnded notifies the listeners about the result of the task's action.
+func (f *flowRunner) notifyTaskEnded(result TaskResult) {
+	for _, listener := range f.listeners {
+		switch result.Status {
+		case "FAIL":
+			listener.TaskFailed(result.Name, result.Duration)
+		case "SKIP":
+			listener.TaskSkipped(result.Name, result.Duration)
+		default:
+			listener.TaskPassed(result.Name, result.Duration)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package goyek_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek"
+)
+
+type recordingListener struct {
+	goyek.NoopListener
+	events []string
+}
+
+func (l *recordingListener) TaskStarted(name string) {
+	l.events = append(l.events, "start "+name)
+}
+
+func (l *recordingListener) TaskPassed(name string, d time.Duration) {
+	l.events = append(l.events, "pass "+name)
+}
+
+func (l *recordingListener) TaskFailed(name string, d time.Duration) {
+	l.events = append(l.events, "fail "+name)
+}
+
+func Test_AddListener(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	first := &recordingListener{}
+	second := &recordingListener{}
+	flow.AddListener(first)
+	flow.AddListener(second)
+	passing := flow.Register(goyek.Task{
+		Name:   "passing",
+		Action: func(tf *goyek.TF) {},
+	})
+	skipped := flow.Register(goyek.Task{
+		Name:   "skipped",
+		Action: func(tf *goyek.TF) { tf.Skip() },
+		Deps:   goyek.Deps{passing},
+	})
+	flow.Register(goyek.Task{
+		Name:   "failing",
+		Action: func(tf *goyek.TF) { tf.Fail() },
+		Deps:   goyek.Deps{skipped},
+	})
+
+	exitCode := flow.Run(context.Background(), "failing")
+
+	want := []string{"start passing", "pass passing", "start skipped", "start failing", "fail failing"}
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertEqual(t, first.events, want, "should notify the first listener")
+	assertEqual(t, second.events, want, "should notify the second listener")
+}
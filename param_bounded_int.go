@@ -0,0 +1,60 @@
+package goyek
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// BoundedIntParam represents a named integer parameter that can be registered.
+// Its value is restricted to the range from Min to Max, inclusive.
+type BoundedIntParam struct {
+	Name    string
+	Usage   string
+	Default int
+	Min     int
+	Max     int
+	ParamOptions
+}
+
+// RegisterBoundedIntParam registers an integer parameter restricted to a range of values.
+// It panics if Min is greater than Max or if the default value is out of the range.
+func (f *Taskflow) RegisterBoundedIntParam(p BoundedIntParam) RegisteredIntParam {
+	if p.Min > p.Max {
+		panic(fmt.Sprintf("%s parameter has invalid range: %d..%d", p.Name, p.Min, p.Max))
+	}
+	if p.Default < p.Min || p.Default > p.Max {
+		panic(fmt.Sprintf("%s parameter has invalid default value: must be in range %d..%d", p.Name, p.Min, p.Max))
+	}
+	valGetter := func() ParamValue {
+		return &boundedIntValue{value: p.Default, min: p.Min, max: p.Max}
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	regParam.hint = fmt.Sprintf("range %d..%d", p.Min, p.Max)
+	f.registerParam(regParam)
+	return RegisteredIntParam{regParam}
+}
+
+type boundedIntValue struct {
+	value int
+	min   int
+	max   int
+}
+
+func (value *boundedIntValue) Set(s string) error {
+	v, err := strconv.ParseInt(s, 0, strconv.IntSize)
+	if err != nil {
+		return errors.New("parse error")
+	}
+	if int(v) < value.min || int(v) > value.max {
+		return fmt.Errorf("must be in range %d..%d", value.min, value.max)
+	}
+	value.value = int(v)
+	return nil
+}
+
+func (value *boundedIntValue) Get() interface{} { return value.value }
+
+func (value *boundedIntValue) String() string { return strconv.Itoa(value.value) }
+
+func (value *boundedIntValue) IsBool() bool { return false }
@@ -0,0 +1,69 @@
+package goyek_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_bounded_int_param(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		exitCode int
+		value    int
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, value: 4},
+		{args: []string{"-workers=1"}, exitCode: goyek.CodePass, value: 1},
+		{args: []string{"-workers", "16"}, exitCode: goyek.CodePass, value: 16},
+
+		{args: []string{"-workers=0"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-workers=17"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-workers=many"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{Output: &strings.Builder{}}
+			param := flow.RegisterBoundedIntParam(goyek.BoundedIntParam{
+				Name:    "workers",
+				Default: 4,
+				Min:     1,
+				Max:     16,
+			})
+			var got int
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_bounded_int_param_help(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterBoundedIntParam(goyek.BoundedIntParam{
+		Name:    "workers",
+		Default: 4,
+		Min:     1,
+		Max:     16,
+	})
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) {}, []string{"-h"})
+
+	assertEqual(t, exitCode, 0, "exit code should be OK")
+	assertContains(t, sb.String(), "Default: 4 (range 1..16)", "should print the range")
+}
+
+func Test_bounded_int_param_invalid_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+
+	act := func() {
+		flow.RegisterBoundedIntParam(goyek.BoundedIntParam{Name: "workers", Min: 1, Max: 16})
+	}
+
+	assertPanics(t, act, "should panic when the default value is out of the range")
+}
@@ -0,0 +1,116 @@
+package goyek
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSizeParam represents a named byte size parameter that can be registered.
+// The values can have a SI (e.g. "10MB") or IEC (e.g. "1GiB") suffix.
+// The value must be a whole number of bytes, e.g. "1.5KiB" is valid but "1.5" is not.
+type ByteSizeParam struct {
+	Name    string
+	Usage   string
+	Default int64 // the number of bytes
+	ParamOptions
+}
+
+// RegisteredByteSizeParam represents a registered byte size parameter.
+type RegisteredByteSizeParam struct {
+	registeredParam
+}
+
+// Get returns the number of bytes of the parameter in the given flow.
+func (p RegisteredByteSizeParam) Get(tf *TF) int64 {
+	value := p.value(tf)
+	return value.Get().(int64)
+}
+
+// RegisterByteSizeParam registers a byte size parameter.
+func (f *Taskflow) RegisterByteSizeParam(p ByteSizeParam) RegisteredByteSizeParam {
+	valGetter := func() ParamValue {
+		value := byteSizeValue(p.Default)
+		return &value
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredByteSizeParam{regParam}
+}
+
+var byteSizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"ki":  1 << 10,
+	"kib": 1 << 10,
+	"mi":  1 << 20,
+	"mib": 1 << 20,
+	"gi":  1 << 30,
+	"gib": 1 << 30,
+	"ti":  1 << 40,
+	"tib": 1 << 40,
+}
+
+// byteSizeFormatUnits are used to format the values, from the biggest unit.
+var byteSizeFormatUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+}
+
+type byteSizeValue int64
+
+func (value *byteSizeValue) Set(s string) error {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	unit, ok := byteSizeUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return errors.New("parse error")
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return errors.New("parse error")
+	}
+	size := n * unit
+	// float64(math.MaxInt64) is 2^63 which does not fit in int64
+	if size >= math.MaxInt64 {
+		return errors.New("value out of range")
+	}
+	if size != math.Trunc(size) {
+		return errors.New("not a whole number of bytes")
+	}
+	*value = byteSizeValue(size)
+	return nil
+}
+
+func (value *byteSizeValue) Get() interface{} { return int64(*value) }
+
+func (value *byteSizeValue) String() string {
+	size := int64(*value)
+	for _, unit := range byteSizeFormatUnits {
+		if size != 0 && size%unit.size == 0 {
+			return strconv.FormatInt(size/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(size, 10) + "B"
+}
+
+func (value *byteSizeValue) IsBool() bool { return false }
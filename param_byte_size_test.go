@@ -0,0 +1,60 @@
+package goyek_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_byte_size_param(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		exitCode int
+		value    int64
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, value: 1 << 20},
+		{args: []string{"-size=512"}, exitCode: goyek.CodePass, value: 512},
+		{args: []string{"-size=10MB"}, exitCode: goyek.CodePass, value: 10000000},
+		{args: []string{"-size", "512kb"}, exitCode: goyek.CodePass, value: 512000},
+		{args: []string{"-size", "1GiB"}, exitCode: goyek.CodePass, value: 1 << 30},
+		{args: []string{"-size", "1.5KiB"}, exitCode: goyek.CodePass, value: 1536},
+
+		{args: []string{"-size=10XB"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-size=MB"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-size=100000000TB"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-size=8388608TiB"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-size=1.5"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterByteSizeParam(goyek.ByteSizeParam{
+				Name:    "size",
+				Default: 1 << 20,
+			})
+			var got int64
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_byte_size_param_help(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterByteSizeParam(goyek.ByteSizeParam{
+		Name:    "size",
+		Default: 10 << 20,
+	})
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) {}, []string{"-h"})
+
+	assertEqual(t, exitCode, 0, "exit code should be OK")
+	assertContains(t, sb.String(), "Default: 10MiB", "should print the default value with a unit")
+}
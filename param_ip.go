@@ -0,0 +1,75 @@
+package goyek
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// IPParam represents a named IP address parameter that can be registered.
+// Both IPv4 and IPv6 addresses are supported.
+type IPParam struct {
+	Name    string
+	Usage   string
+	Default string // it must be a valid IP address or empty
+	ParamOptions
+}
+
+// RegisteredIPParam represents a registered IP address parameter.
+type RegisteredIPParam struct {
+	registeredParam
+}
+
+// Get returns the IP address of the parameter in the given flow.
+// It returns nil if the default value is empty and the parameter was not set.
+func (p RegisteredIPParam) Get(tf *TF) net.IP {
+	value := p.value(tf)
+	return value.Get().(net.IP)
+}
+
+// RegisterIPParam registers an IP address parameter.
+// It panics if the default value is not empty and is not a valid IP address.
+func (f *Taskflow) RegisterIPParam(p IPParam) RegisteredIPParam {
+	if p.Default != "" {
+		if err := (&ipValue{}).Set(p.Default); err != nil {
+			panic(fmt.Sprintf("%s parameter has invalid default value: %v", p.Name, err))
+		}
+	}
+	valGetter := func() ParamValue {
+		value := &ipValue{}
+		if p.Default != "" {
+			value.Set(p.Default) //nolint:errcheck // validated during registration
+		}
+		return value
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredIPParam{regParam}
+}
+
+type ipValue net.IP
+
+func (value *ipValue) Set(s string) error {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return errors.New("invalid IP address")
+	}
+	*value = ipValue(ip)
+	return nil
+}
+
+func (value *ipValue) Get() interface{} {
+	if len(*value) == 0 {
+		return net.IP(nil)
+	}
+	return net.IP(*value)
+}
+
+func (value *ipValue) String() string {
+	if len(*value) == 0 {
+		return ""
+	}
+	return net.IP(*value).String()
+}
+
+func (value *ipValue) IsBool() bool { return false }
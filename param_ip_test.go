@@ -0,0 +1,68 @@
+package goyek_test
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_ip_param(t *testing.T) {
+	tt := []struct {
+		defaultValue string
+		args         []string
+
+		exitCode int
+		value    string
+	}{
+		{defaultValue: "127.0.0.1", args: []string{}, exitCode: goyek.CodePass, value: "127.0.0.1"},
+		{defaultValue: "", args: []string{"-ip=10.0.0.1"}, exitCode: goyek.CodePass, value: "10.0.0.1"},
+		{defaultValue: "", args: []string{"-ip", "::1"}, exitCode: goyek.CodePass, value: "::1"},
+
+		{defaultValue: "", args: []string{"-ip", "localhost"}, exitCode: goyek.CodeInvalidArgs},
+		{defaultValue: "", args: []string{"-ip", "256.0.0.1"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterIPParam(goyek.IPParam{
+				Name:    "ip",
+				Default: tc.defaultValue,
+			})
+			var got string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf).String() }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			if tc.exitCode == goyek.CodePass {
+				assertEqual(t, got, tc.value, "value should match")
+			}
+		})
+	}
+}
+
+func Test_ip_param_empty_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	param := flow.RegisterIPParam(goyek.IPParam{
+		Name: "ip",
+	})
+	got := net.IP{}
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, nil)
+
+	assertEqual(t, exitCode, goyek.CodePass, "exit code should be OK")
+	assertEqual(t, got, net.IP(nil), "should return nil")
+}
+
+func Test_ip_param_invalid_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	act := func() {
+		flow.RegisterIPParam(goyek.IPParam{
+			Name:    "ip",
+			Default: "localhost",
+		})
+	}
+
+	assertPanics(t, act, "should panic when the default value is not a valid IP address")
+}
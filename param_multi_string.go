@@ -0,0 +1,56 @@
+package goyek
+
+import "strings"
+
+// MultiStringParam represents a named string parameter that can be set multiple times.
+// Each occurrence of the flag appends its value, e.g. -tag foo -tag bar.
+type MultiStringParam struct {
+	Name    string
+	Usage   string
+	Default []string // used if the parameter is not set at all
+	ParamOptions
+}
+
+// RegisteredMultiStringParam represents a registered multi-string parameter.
+type RegisteredMultiStringParam struct {
+	registeredParam
+}
+
+// Get returns the values of the parameter in the given flow.
+func (p RegisteredMultiStringParam) Get(tf *TF) []string {
+	value := p.value(tf)
+	return value.Get().([]string)
+}
+
+// RegisterMultiStringParam registers a string parameter that can be set multiple times.
+func (f *Taskflow) RegisterMultiStringParam(p MultiStringParam) RegisteredMultiStringParam {
+	defaultValue := append([]string(nil), p.Default...)
+	valGetter := func() ParamValue {
+		return &multiStringValue{values: append([]string(nil), defaultValue...)}
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredMultiStringParam{regParam}
+}
+
+type multiStringValue struct {
+	values []string
+	set    bool // the default values were replaced
+}
+
+func (value *multiStringValue) Set(s string) error {
+	if !value.set {
+		value.values = nil
+		value.set = true
+	}
+	value.values = append(value.values, s)
+	return nil
+}
+
+func (value *multiStringValue) Get() interface{} {
+	return append([]string(nil), value.values...)
+}
+
+func (value *multiStringValue) String() string { return strings.Join(value.values, ",") }
+
+func (value *multiStringValue) IsBool() bool { return false }
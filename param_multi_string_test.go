@@ -0,0 +1,70 @@
+package goyek_test
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_multi_string_param(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		exitCode int
+		value    []string
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, value: []string{"default"}},
+		{args: []string{"-tag", "foo"}, exitCode: goyek.CodePass, value: []string{"foo"}},
+		{args: []string{"-tag", "foo", "-tag=bar"}, exitCode: goyek.CodePass, value: []string{"foo", "bar"}},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterMultiStringParam(goyek.MultiStringParam{
+				Name:    "tag",
+				Default: []string{"default"},
+			})
+			var got []string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_multi_string_param_env(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		value []string
+	}{
+		{args: []string{}, value: []string{"from-env"}},
+		{args: []string{"-tag", "foo", "-tag=bar"}, value: []string{"foo", "bar"}},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			os.Setenv("GOYEK_TEST_PARAM", "from-env")
+			defer os.Unsetenv("GOYEK_TEST_PARAM")
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterMultiStringParam(goyek.MultiStringParam{
+				Name:    "tag",
+				Default: []string{"default"},
+				ParamOptions: goyek.ParamOptions{
+					EnvVar: "GOYEK_TEST_PARAM",
+				},
+			})
+			var got []string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, goyek.CodePass, "exit code should match")
+			assertEqual(t, got, tc.value, "the values set via CLI should replace the value from the environment variable")
+		})
+	}
+}
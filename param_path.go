@@ -0,0 +1,84 @@
+package goyek
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Path types which can be used as PathParam.Type.
+const (
+	PathTypeAny  = "any"
+	PathTypeFile = "file"
+	PathTypeDir  = "dir"
+)
+
+// PathParam represents a named file system path parameter that can be registered.
+type PathParam struct {
+	Name    string
+	Usage   string
+	Default string // it is not checked even if MustExist is set
+	ParamOptions
+
+	MustExist bool   // the path set via CLI has to exist
+	Type      string // PathTypeFile, PathTypeDir, or PathTypeAny (default); checked only if MustExist is set
+}
+
+// RegisteredPathParam represents a registered file system path parameter.
+type RegisteredPathParam struct {
+	registeredParam
+}
+
+// Get returns the path of the parameter in the given flow.
+func (p RegisteredPathParam) Get(tf *TF) string {
+	value := p.value(tf)
+	return value.Get().(string)
+}
+
+// RegisterPathParam registers a file system path parameter.
+// It panics if the Type is invalid.
+func (f *Taskflow) RegisterPathParam(p PathParam) RegisteredPathParam {
+	pathType := p.Type
+	switch pathType {
+	case "":
+		pathType = PathTypeAny
+	case PathTypeAny, PathTypeFile, PathTypeDir:
+	default:
+		panic(fmt.Sprintf("%s parameter has invalid path type: %s", p.Name, p.Type))
+	}
+	valGetter := func() ParamValue {
+		return &pathValue{path: p.Default, mustExist: p.MustExist, pathType: pathType}
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredPathParam{regParam}
+}
+
+type pathValue struct {
+	path      string
+	mustExist bool
+	pathType  string
+}
+
+func (value *pathValue) Set(s string) error {
+	if value.mustExist {
+		fi, err := os.Stat(s)
+		if err != nil {
+			return err
+		}
+		switch {
+		case value.pathType == PathTypeFile && !fi.Mode().IsRegular():
+			return errors.New("must be a regular file")
+		case value.pathType == PathTypeDir && !fi.IsDir():
+			return errors.New("must be a directory")
+		}
+	}
+	value.path = s
+	return nil
+}
+
+func (value *pathValue) Get() interface{} { return value.path }
+
+func (value *pathValue) String() string { return value.path }
+
+func (value *pathValue) IsBool() bool { return false }
@@ -0,0 +1,69 @@
+package goyek_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_path_param(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	file := filepath.Join(dir, "file.txt")
+	err := ioutil.WriteFile(file, []byte("content"), 0600)
+	requireEqual(t, err, nil, "should create a file")
+	missing := filepath.Join(dir, "missing")
+
+	tt := []struct {
+		mustExist bool
+		pathType  string
+		args      []string
+
+		exitCode int
+		value    string
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, value: "default"},
+		{args: []string{"-path", missing}, exitCode: goyek.CodePass, value: missing},
+		{mustExist: true, args: []string{"-path", file}, exitCode: goyek.CodePass, value: file},
+		{mustExist: true, pathType: goyek.PathTypeFile, args: []string{"-path", file}, exitCode: goyek.CodePass, value: file},
+		{mustExist: true, pathType: goyek.PathTypeDir, args: []string{"-path", dir}, exitCode: goyek.CodePass, value: dir},
+		{pathType: goyek.PathTypeDir, args: []string{"-path", file}, exitCode: goyek.CodePass, value: file},
+
+		{mustExist: true, args: []string{"-path", missing}, exitCode: goyek.CodeInvalidArgs},
+		{mustExist: true, pathType: goyek.PathTypeFile, args: []string{"-path", dir}, exitCode: goyek.CodeInvalidArgs},
+		{mustExist: true, pathType: goyek.PathTypeDir, args: []string{"-path", file}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterPathParam(goyek.PathParam{
+				Name:      "path",
+				Default:   "default",
+				MustExist: tc.mustExist,
+				Type:      tc.pathType,
+			})
+			var got string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_path_param_invalid_type(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	act := func() {
+		flow.RegisterPathParam(goyek.PathParam{
+			Name: "path",
+			Type: "socket",
+		})
+	}
+
+	assertPanics(t, act, "should panic when the path type is invalid")
+}
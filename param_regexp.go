@@ -0,0 +1,59 @@
+package goyek
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RegexpParam represents a named regular expression parameter that can be registered.
+type RegexpParam struct {
+	Name    string
+	Usage   string
+	Default string // it must be a valid regular expression
+	ParamOptions
+}
+
+// RegisteredRegexpParam represents a registered regular expression parameter.
+type RegisteredRegexpParam struct {
+	registeredParam
+}
+
+// Get returns the compiled regular expression of the parameter in the given flow.
+func (p RegisteredRegexpParam) Get(tf *TF) *regexp.Regexp {
+	value := p.value(tf)
+	return value.Get().(*regexp.Regexp)
+}
+
+// RegisterRegexpParam registers a regular expression parameter.
+// It panics if the default value cannot be compiled.
+func (f *Taskflow) RegisterRegexpParam(p RegexpParam) RegisteredRegexpParam {
+	defaultRegexp, err := regexp.Compile(p.Default)
+	if err != nil {
+		panic(fmt.Sprintf("%s parameter has invalid default value: %v", p.Name, err))
+	}
+	valGetter := func() ParamValue {
+		return &regexpValue{defaultRegexp}
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredRegexpParam{regParam}
+}
+
+type regexpValue struct {
+	re *regexp.Regexp
+}
+
+func (value *regexpValue) Set(s string) error {
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return err
+	}
+	value.re = re
+	return nil
+}
+
+func (value *regexpValue) Get() interface{} { return value.re }
+
+func (value *regexpValue) String() string { return value.re.String() }
+
+func (value *regexpValue) IsBool() bool { return false }
@@ -0,0 +1,51 @@
+package goyek_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_regexp_param(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		exitCode int
+		value    string
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, value: ".*"},
+		{args: []string{"-filter=^a+$"}, exitCode: goyek.CodePass, value: "^a+$"},
+		{args: []string{"-filter", "[0-9]"}, exitCode: goyek.CodePass, value: "[0-9]"},
+
+		{args: []string{"-filter=a("}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterRegexpParam(goyek.RegexpParam{
+				Name:    "filter",
+				Default: ".*",
+			})
+			var got string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf).String() }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_regexp_param_invalid_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	act := func() {
+		flow.RegisterRegexpParam(goyek.RegexpParam{
+			Name:    "filter",
+			Default: "a(",
+		})
+	}
+
+	assertPanics(t, act, "should panic when the default value is not a valid regular expression")
+}
@@ -0,0 +1,89 @@
+package goyek
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// StringDefaultParam represents a named parameter of the given Kind
+// whose default value is provided as a string, e.g. read from a configuration file.
+// The supported kinds are "bool", "int", "string", "float64" and "duration".
+type StringDefaultParam struct {
+	Name    string
+	Usage   string
+	Kind    string
+	Default string // it is parsed the same way as the values set via CLI
+	ParamOptions
+}
+
+// RegisterStringDefaultParam registers a parameter of the given kind.
+// The value returned by the Get method of the registered parameter
+// has the Go type corresponding to the kind, e.g. time.Duration for "duration".
+// An error is returned if the kind is not supported or the default value cannot be parsed.
+func (f *Taskflow) RegisterStringDefaultParam(p StringDefaultParam) (RegisteredValueParam, error) {
+	newKindValue, ok := kindValues[p.Kind]
+	if !ok {
+		return RegisteredValueParam{}, fmt.Errorf("%s parameter has unsupported kind: %s", p.Name, p.Kind)
+	}
+	if err := newKindValue().Set(p.Default); err != nil {
+		return RegisteredValueParam{}, fmt.Errorf("%s parameter has invalid default value: %v", p.Name, err)
+	}
+	valGetter := func() ParamValue {
+		value := newKindValue()
+		value.Set(p.Default) //nolint:errcheck // validated during registration
+		return value
+	}
+	return f.RegisterValueParam(ValueParam{
+		Name:         p.Name,
+		Usage:        p.Usage,
+		NewValue:     valGetter,
+		ParamOptions: p.ParamOptions,
+	}), nil
+}
+
+// kindValues contains the value factories for the kinds supported by StringDefaultParam.
+var kindValues = map[string]func() ParamValue{
+	"bool":     func() ParamValue { return new(boolValue) },
+	"int":      func() ParamValue { return new(intValue) },
+	"string":   func() ParamValue { return new(stringValue) },
+	"float64":  func() ParamValue { return new(float64Value) },
+	"duration": func() ParamValue { return new(durationValue) },
+}
+
+type float64Value float64
+
+func (value *float64Value) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return errors.New("parse error")
+	}
+	*value = float64Value(v)
+	return nil
+}
+
+func (value *float64Value) Get() interface{} { return float64(*value) }
+
+func (value *float64Value) String() string {
+	return strconv.FormatFloat(float64(*value), 'g', -1, 64)
+}
+
+func (value *float64Value) IsBool() bool { return false }
+
+type durationValue time.Duration
+
+func (value *durationValue) Set(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.New("parse error")
+	}
+	*value = durationValue(v)
+	return nil
+}
+
+func (value *durationValue) Get() interface{} { return time.Duration(*value) }
+
+func (value *durationValue) String() string { return time.Duration(*value).String() }
+
+func (value *durationValue) IsBool() bool { return false }
@@ -0,0 +1,54 @@
+package goyek_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_string_default_param(t *testing.T) {
+	tt := []struct {
+		kind         string
+		defaultValue string
+		args         []string
+
+		value interface{}
+	}{
+		{kind: "bool", defaultValue: "true", value: true},
+		{kind: "bool", defaultValue: "false", args: []string{"-p"}, value: true},
+		{kind: "int", defaultValue: "3", value: 3},
+		{kind: "string", defaultValue: "", args: []string{"-p=text"}, value: "text"},
+		{kind: "float64", defaultValue: "0.5", value: 0.5},
+		{kind: "duration", defaultValue: "1m", args: []string{"-p=2s"}, value: 2 * time.Second},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param, err := flow.RegisterStringDefaultParam(goyek.StringDefaultParam{
+				Name:    "p",
+				Kind:    tc.kind,
+				Default: tc.defaultValue,
+			})
+			requireEqual(t, err, nil, "should register the parameter")
+			var got interface{}
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, goyek.CodePass, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_string_default_param_errors(t *testing.T) {
+	flow := &goyek.Taskflow{}
+
+	_, kindErr := flow.RegisterStringDefaultParam(goyek.StringDefaultParam{Name: "p", Kind: "complex128"})
+	_, defaultErr := flow.RegisterStringDefaultParam(goyek.StringDefaultParam{Name: "p", Kind: "int", Default: "three"})
+
+	assertEqual(t, kindErr.Error(), "p parameter has unsupported kind: complex128", "should return an error for unknown kind")
+	assertEqual(t, defaultErr.Error(), "p parameter has invalid default value: parse error", "should return an error for invalid default")
+}
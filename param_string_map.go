@@ -0,0 +1,77 @@
+package goyek
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// StringMapParam represents a named parameter of key-value pairs that can be registered.
+// Each occurrence of the flag adds a pair in the key=value format, e.g. -header Accept=text/plain.
+type StringMapParam struct {
+	Name    string
+	Usage   string
+	Default map[string]string // used if the parameter is not set at all
+	ParamOptions
+}
+
+// RegisteredStringMapParam represents a registered key-value pairs parameter.
+type RegisteredStringMapParam struct {
+	registeredParam
+}
+
+// Get returns the key-value pairs of the parameter in the given flow.
+func (p RegisteredStringMapParam) Get(tf *TF) map[string]string {
+	value := p.value(tf)
+	return value.Get().(map[string]string)
+}
+
+// RegisterStringMapParam registers a key-value pairs parameter.
+func (f *Taskflow) RegisterStringMapParam(p StringMapParam) RegisteredStringMapParam {
+	defaultValue := copyStringMap(p.Default)
+	valGetter := func() ParamValue {
+		return &stringMapValue{values: copyStringMap(defaultValue)}
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredStringMapParam{regParam}
+}
+
+type stringMapValue struct {
+	values map[string]string
+	set    bool // the default values were replaced
+}
+
+func (value *stringMapValue) Set(s string) error {
+	split := strings.SplitN(s, "=", 2)     //nolint:gomnd // ignore
+	if len(split) != 2 || split[0] == "" { //nolint:gomnd // ignore
+		return errors.New("must be in key=value format")
+	}
+	if !value.set {
+		value.values = map[string]string{}
+		value.set = true
+	}
+	value.values[split[0]] = split[1]
+	return nil
+}
+
+func (value *stringMapValue) Get() interface{} { return copyStringMap(value.values) }
+
+func (value *stringMapValue) String() string {
+	pairs := make([]string, 0, len(value.values))
+	for k, v := range value.values {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (value *stringMapValue) IsBool() bool { return false }
+
+func copyStringMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
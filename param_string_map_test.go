@@ -0,0 +1,90 @@
+package goyek_test
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_string_map_param(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		exitCode int
+		value    map[string]string
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, value: map[string]string{"a": "1", "b": "2"}},
+		{args: []string{"-env", "c=3"}, exitCode: goyek.CodePass, value: map[string]string{"c": "3"}},
+		{args: []string{"-env", "c=3", "-env=d=x=y"}, exitCode: goyek.CodePass, value: map[string]string{"c": "3", "d": "x=y"}},
+		{args: []string{"-env", "c="}, exitCode: goyek.CodePass, value: map[string]string{"c": ""}},
+
+		{args: []string{"-env", "c"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-env", "=3"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterStringMapParam(goyek.StringMapParam{
+				Name:    "env",
+				Default: map[string]string{"a": "1", "b": "2"},
+			})
+			var got map[string]string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			if tc.exitCode == goyek.CodePass {
+				assertEqual(t, got, tc.value, "value should match")
+			}
+		})
+	}
+}
+
+func Test_string_map_param_env(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		value map[string]string
+	}{
+		{args: []string{}, value: map[string]string{"e": "env"}},
+		{args: []string{"-env", "c=3", "-env=d=4"}, value: map[string]string{"c": "3", "d": "4"}},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			os.Setenv("GOYEK_TEST_PARAM", "e=env")
+			defer os.Unsetenv("GOYEK_TEST_PARAM")
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterStringMapParam(goyek.StringMapParam{
+				Name:    "env",
+				Default: map[string]string{"a": "1"},
+				ParamOptions: goyek.ParamOptions{
+					EnvVar: "GOYEK_TEST_PARAM",
+				},
+			})
+			var got map[string]string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, goyek.CodePass, "exit code should match")
+			assertEqual(t, got, tc.value, "the pairs set via CLI should replace the map from the environment variable")
+		})
+	}
+}
+
+func Test_string_map_param_help(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterStringMapParam(goyek.StringMapParam{
+		Name:    "env",
+		Default: map[string]string{"b": "2", "a": "1"},
+	})
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) {}, []string{"-h"})
+
+	assertEqual(t, exitCode, goyek.CodePass, "exit code should be OK")
+	assertContains(t, sb.String(), "Default: a=1,b=2", "should print the sorted pairs")
+}
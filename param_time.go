@@ -0,0 +1,66 @@
+package goyek
+
+import (
+	"errors"
+	"time"
+)
+
+// TimeParam represents a named time parameter that can be registered.
+// The value is set using the RFC 3339 format (e.g. 2006-01-02T15:04:05Z07:00)
+// or the date-only format (e.g. 2006-01-02).
+type TimeParam struct {
+	Name    string
+	Usage   string
+	Default time.Time
+	ParamOptions
+}
+
+// RegisteredTimeParam represents a registered time parameter.
+type RegisteredTimeParam struct {
+	registeredParam
+}
+
+// Get returns the time value of the parameter in the given flow.
+func (p RegisteredTimeParam) Get(tf *TF) time.Time {
+	value := p.value(tf)
+	return value.Get().(time.Time)
+}
+
+// RegisterTimeParam registers a time parameter.
+func (f *Taskflow) RegisterTimeParam(p TimeParam) RegisteredTimeParam {
+	valGetter := func() ParamValue {
+		value := timeValue(p.Default)
+		return &value
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	regParam.hint = "RFC3339 or YYYY-MM-DD"
+	f.registerParam(regParam)
+	return RegisteredTimeParam{regParam}
+}
+
+const dateLayout = "2006-01-02"
+
+type timeValue time.Time
+
+func (value *timeValue) Set(s string) error {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		if t, err = time.Parse(dateLayout, s); err != nil {
+			return errors.New("must be in RFC3339 or YYYY-MM-DD format")
+		}
+	}
+	*value = timeValue(t)
+	return nil
+}
+
+func (value *timeValue) Get() interface{} { return time.Time(*value) }
+
+func (value *timeValue) String() string {
+	t := time.Time(*value)
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (value *timeValue) IsBool() bool { return false }
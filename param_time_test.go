@@ -0,0 +1,58 @@
+package goyek_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_time_param(t *testing.T) {
+	defaultValue := time.Date(2021, 6, 21, 12, 30, 0, 0, time.UTC)
+	tt := []struct {
+		args []string
+
+		exitCode int
+		value    time.Time
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, value: defaultValue},
+		{args: []string{"-since=2021-07-01T08:00:00Z"}, exitCode: goyek.CodePass, value: time.Date(2021, 7, 1, 8, 0, 0, 0, time.UTC)},
+		{args: []string{"-since", "2021-07-01"}, exitCode: goyek.CodePass, value: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)},
+
+		{args: []string{"-since", "yesterday"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-since", "2021-13-01"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterTimeParam(goyek.TimeParam{
+				Name:    "since",
+				Default: defaultValue,
+			})
+			var got time.Time
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertTrue(t, got.Equal(tc.value), "value should match")
+		})
+	}
+}
+
+func Test_time_param_usage(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterTimeParam(goyek.TimeParam{
+		Name:    "since",
+		Default: time.Date(2021, 6, 21, 12, 30, 0, 0, time.UTC),
+	})
+	flow.Register(goyek.Task{Name: "task", Params: goyek.Params{param}})
+
+	flow.Run(context.Background(), "-h")
+
+	assertContains(t, sb.String(), "Default: 2021-06-21T12:30:00Z (RFC3339 or YYYY-MM-DD)", "should print the default in RFC3339")
+}
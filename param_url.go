@@ -0,0 +1,72 @@
+package goyek
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// URLParam represents a named URL parameter that can be registered.
+// The URL must have a scheme.
+type URLParam struct {
+	Name    string
+	Usage   string
+	Default string // it must be a valid URL or empty
+	ParamOptions
+}
+
+// RegisteredURLParam represents a registered URL parameter.
+type RegisteredURLParam struct {
+	registeredParam
+}
+
+// Get returns the parsed URL of the parameter in the given flow.
+// It returns nil if the default value is empty and the parameter was not set.
+func (p RegisteredURLParam) Get(tf *TF) *url.URL {
+	value := p.value(tf)
+	return value.Get().(*url.URL)
+}
+
+// RegisterURLParam registers a URL parameter.
+// It panics if the default value is not empty and is not a valid URL.
+func (f *Taskflow) RegisterURLParam(p URLParam) RegisteredURLParam {
+	if p.Default != "" {
+		if err := (&urlValue{}).Set(p.Default); err != nil {
+			panic(fmt.Sprintf("%s parameter has invalid default value: %v", p.Name, err))
+		}
+	}
+	valGetter := func() ParamValue {
+		value := &urlValue{}
+		if p.Default != "" {
+			value.Set(p.Default) //nolint:errcheck // validated during registration
+		}
+		return value
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredURLParam{regParam}
+}
+
+type urlValue struct {
+	raw string
+	url *url.URL
+}
+
+func (value *urlValue) Set(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" {
+		return errors.New("missing URL scheme")
+	}
+	value.raw = s
+	value.url = u
+	return nil
+}
+
+func (value *urlValue) Get() interface{} { return value.url }
+
+func (value *urlValue) String() string { return value.raw }
+
+func (value *urlValue) IsBool() bool { return false }
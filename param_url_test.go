@@ -0,0 +1,65 @@
+package goyek_test
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_url_param(t *testing.T) {
+	tt := []struct {
+		defaultValue string
+		args         []string
+
+		exitCode int
+		value    string
+	}{
+		{defaultValue: "https://example.com", args: []string{}, exitCode: goyek.CodePass, value: "https://example.com"},
+		{defaultValue: "", args: []string{"-url=http://localhost:8080/path"}, exitCode: goyek.CodePass, value: "http://localhost:8080/path"},
+
+		{defaultValue: "", args: []string{"-url", "localhost"}, exitCode: goyek.CodeInvalidArgs},
+		{defaultValue: "", args: []string{"-url", "http://[::1"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterURLParam(goyek.URLParam{
+				Name:    "url",
+				Default: tc.defaultValue,
+			})
+			var got string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf).String() }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_url_param_empty_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	param := flow.RegisterURLParam(goyek.URLParam{
+		Name: "url",
+	})
+	got := &url.URL{}
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, nil)
+
+	assertEqual(t, exitCode, goyek.CodePass, "exit code should be OK")
+	assertEqual(t, got, (*url.URL)(nil), "should return nil")
+}
+
+func Test_url_param_invalid_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	act := func() {
+		flow.RegisterURLParam(goyek.URLParam{
+			Name:    "url",
+			Default: "example.com",
+		})
+	}
+
+	assertPanics(t, act, "should panic when the default value is not a valid URL")
+}
@@ -0,0 +1,66 @@
+package goyek
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// UUIDParam represents a named UUID parameter that can be registered.
+// The values are case-insensitive and normalized to lowercase.
+type UUIDParam struct {
+	Name    string
+	Usage   string
+	Default string // it must be a valid UUID or empty
+	ParamOptions
+}
+
+// RegisteredUUIDParam represents a registered UUID parameter.
+type RegisteredUUIDParam struct {
+	registeredParam
+}
+
+// Get returns the lowercase UUID of the parameter in the given flow.
+// It returns an empty string if the default value is empty and the parameter was not set.
+func (p RegisteredUUIDParam) Get(tf *TF) string {
+	value := p.value(tf)
+	return value.Get().(string)
+}
+
+// RegisterUUIDParam registers a UUID parameter.
+// It panics if the default value is not empty and is not a valid UUID.
+func (f *Taskflow) RegisterUUIDParam(p UUIDParam) RegisteredUUIDParam {
+	var defaultValue uuidValue
+	if p.Default != "" {
+		if err := defaultValue.Set(p.Default); err != nil {
+			panic(fmt.Sprintf("%s parameter has invalid default value: %v", p.Name, err))
+		}
+	}
+	valGetter := func() ParamValue {
+		value := defaultValue
+		return &value
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredUUIDParam{regParam}
+}
+
+var uuidRegex = regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$")
+
+type uuidValue string
+
+func (value *uuidValue) Set(s string) error {
+	s = strings.ToLower(s)
+	if !uuidRegex.MatchString(s) {
+		return errors.New("invalid UUID")
+	}
+	*value = uuidValue(s)
+	return nil
+}
+
+func (value *uuidValue) Get() interface{} { return string(*value) }
+
+func (value *uuidValue) String() string { return string(*value) }
+
+func (value *uuidValue) IsBool() bool { return false }
@@ -0,0 +1,53 @@
+package goyek_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_uuid_param(t *testing.T) {
+	tt := []struct {
+		defaultValue string
+		args         []string
+
+		exitCode int
+		value    string
+	}{
+		{defaultValue: "", args: []string{}, exitCode: goyek.CodePass, value: ""},
+		{defaultValue: "123e4567-e89b-12d3-a456-426614174000", args: []string{}, exitCode: goyek.CodePass, value: "123e4567-e89b-12d3-a456-426614174000"},
+		{defaultValue: "", args: []string{"-id=123E4567-E89B-12D3-A456-426614174000"}, exitCode: goyek.CodePass, value: "123e4567-e89b-12d3-a456-426614174000"},
+
+		{defaultValue: "", args: []string{"-id", "123e4567e89b12d3a456426614174000"}, exitCode: goyek.CodeInvalidArgs},
+		{defaultValue: "", args: []string{"-id", "123e4567-e89b-12d3-a456-42661417400g"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterUUIDParam(goyek.UUIDParam{
+				Name:    "id",
+				Default: tc.defaultValue,
+			})
+			var got string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_uuid_param_invalid_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	act := func() {
+		flow.RegisterUUIDParam(goyek.UUIDParam{
+			Name:    "id",
+			Default: "not-a-uuid",
+		})
+	}
+
+	assertPanics(t, act, "should panic when the default value is not a valid UUID")
+}
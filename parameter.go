@@ -3,13 +3,43 @@ package goyek
 import (
 	"errors"
 	"strconv"
+	"strings"
 )
 
+// ParamOptions are the options common to all parameter types.
+type ParamOptions struct {
+	Required   bool     // the parameter has to be set via CLI or EnvVar
+	EnvVar     string   // the environment variable from which the value is set unless it is set via CLI
+	EnvOnly    bool     // the parameter can be set only via EnvVar and is not a CLI flag
+	Deprecated string   // if not empty, a warning with this message is printed when the parameter is set via CLI
+	Aliases    []string // alternative names of the CLI flag
+
+	// ValidateFunc validates the raw value set via CLI or EnvVar.
+	// For the parameters which can be set multiple times, it validates each value.
+	ValidateFunc func(string) error
+}
+
+// registeredParam returns the parameter to register with the options.
+func (o ParamOptions) registeredParam(name, usage string, newValue func() ParamValue) registeredParam {
+	return registeredParam{
+		name:       name,
+		usage:      usage,
+		newValue:   newValue,
+		required:   o.Required,
+		envVar:     o.EnvVar,
+		envOnly:    o.EnvOnly,
+		deprecated: o.Deprecated,
+		aliases:    o.Aliases,
+		validate:   o.ValidateFunc,
+	}
+}
+
 // BoolParam represents a named boolean parameter that can be registered.
 type BoolParam struct {
 	Name    string
 	Usage   string
 	Default bool
+	ParamOptions
 }
 
 // IntParam represents a named integer parameter that can be registered.
@@ -17,6 +47,23 @@ type IntParam struct {
 	Name    string
 	Usage   string
 	Default int
+	ParamOptions
+}
+
+// UintParam represents a named unsigned integer parameter that can be registered.
+type UintParam struct {
+	Name    string
+	Usage   string
+	Default uint
+	ParamOptions
+}
+
+// Int64Param represents a named 64-bit integer parameter that can be registered.
+type Int64Param struct {
+	Name    string
+	Usage   string
+	Default int64
+	ParamOptions
 }
 
 // StringParam represents a named string parameter that can be registered.
@@ -24,6 +71,21 @@ type StringParam struct {
 	Name    string
 	Usage   string
 	Default string
+	ParamOptions
+
+	// Transform normalizes the value, e.g. using strings.ToLower.
+	// It is applied to the default value and to each value that is set.
+	Transform func(string) string
+}
+
+// StringEnumParam represents a named string parameter that can be registered.
+// Its value is restricted to one of the Choices.
+type StringEnumParam struct {
+	Name    string
+	Usage   string
+	Default string
+	Choices []string
+	ParamOptions
 }
 
 // ValueParam represents a named parameter for a custom type that can be registered.
@@ -32,6 +94,7 @@ type ValueParam struct {
 	Name     string
 	Usage    string
 	NewValue func() ParamValue
+	ParamOptions
 }
 
 // ParamValue represents an instance of a generic parameter.
@@ -59,9 +122,16 @@ type RegisteredParam interface {
 
 // registeredParam is a helper struct encapsulating concrete registered parameter type.
 type registeredParam struct {
-	name     string
-	usage    string
-	newValue func() ParamValue
+	name       string
+	usage      string
+	newValue   func() ParamValue
+	required   bool
+	envVar     string
+	envOnly    bool
+	deprecated string
+	aliases    []string
+	validate   func(string) error
+	hint       string // additional information printed in usage next to the default value
 }
 
 // Name returns the key of the parameter.
@@ -69,6 +139,22 @@ func (p registeredParam) Name() string {
 	return p.name
 }
 
+// flags returns the names of the CLI flags, without the leading dash, which set the parameter.
+func (p registeredParam) flags() []string {
+	if p.envOnly {
+		return nil
+	}
+	names := append([]string{p.name}, p.aliases...)
+	if !p.newValue().IsBool() {
+		return names
+	}
+	flags := names
+	for _, name := range names {
+		flags = append(flags, "no-"+name)
+	}
+	return flags
+}
+
 func (p registeredParam) value(tf *TF) ParamValue {
 	value, existing := tf.paramValues[p.name]
 	if !existing {
@@ -119,6 +205,12 @@ func (p RegisteredBoolParam) Get(tf *TF) bool {
 	return value.Get().(bool)
 }
 
+// Default returns the default value of the parameter.
+// It can be used before the taskflow is run.
+func (p RegisteredBoolParam) Default() bool {
+	return p.newValue().Get().(bool)
+}
+
 type intValue int
 
 func (value *intValue) Set(s string) error {
@@ -147,6 +239,130 @@ func (p RegisteredIntParam) Get(tf *TF) int {
 	return value.Get().(int)
 }
 
+// Default returns the default value of the parameter.
+// It can be used before the taskflow is run.
+func (p RegisteredIntParam) Default() int {
+	return p.newValue().Get().(int)
+}
+
+// verbosityValue is an integer value which can be also set like a boolean flag.
+type verbosityValue int
+
+func (value *verbosityValue) Set(s string) error {
+	if len(s) == 0 {
+		*value = 1
+		return nil
+	}
+	if v, err := strconv.Atoi(s); err == nil {
+		if v < 0 {
+			return errors.New("must not be negative")
+		}
+		*value = verbosityValue(v)
+		return nil
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return errors.New("must be a non-negative integer or a boolean")
+	}
+	*value = 0
+	if v {
+		*value = 1
+	}
+	return nil
+}
+
+func (value *verbosityValue) Get() interface{} { return int(*value) }
+
+func (value *verbosityValue) String() string { return strconv.Itoa(int(*value)) }
+
+func (value *verbosityValue) IsBool() bool { return true }
+
+// RegisteredVerboseParam represents the out-of-the-box verbose parameter.
+// It is backed by the verbosity parameter.
+type RegisteredVerboseParam struct {
+	registeredParam
+}
+
+// Get returns true if the verbosity level in the given flow is at least 1.
+func (p RegisteredVerboseParam) Get(tf *TF) bool {
+	value := p.value(tf)
+	return value.Get().(int) >= 1
+}
+
+// Default returns true if the default verbosity level is at least 1.
+// It can be used before the taskflow is run.
+func (p RegisteredVerboseParam) Default() bool {
+	return p.newValue().Get().(int) >= 1
+}
+
+type uintValue uint
+
+func (value *uintValue) Set(s string) error {
+	v, err := strconv.ParseUint(s, 0, strconv.IntSize)
+	if err != nil {
+		err = errors.New("parse error")
+	}
+	*value = uintValue(v)
+	return err
+}
+
+func (value *uintValue) Get() interface{} { return uint(*value) }
+
+func (value *uintValue) String() string { return strconv.FormatUint(uint64(*value), 10) }
+
+func (value *uintValue) IsBool() bool { return false }
+
+// RegisteredUintParam represents a registered unsigned integer parameter.
+type RegisteredUintParam struct {
+	registeredParam
+}
+
+// Get returns the unsigned integer value of the parameter in the given flow.
+func (p RegisteredUintParam) Get(tf *TF) uint {
+	value := p.value(tf)
+	return value.Get().(uint)
+}
+
+// Default returns the default value of the parameter.
+// It can be used before the taskflow is run.
+func (p RegisteredUintParam) Default() uint {
+	return p.newValue().Get().(uint)
+}
+
+type int64Value int64
+
+func (value *int64Value) Set(s string) error {
+	v, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		err = errors.New("parse error")
+	}
+	*value = int64Value(v)
+	return err
+}
+
+func (value *int64Value) Get() interface{} { return int64(*value) }
+
+func (value *int64Value) String() string { return strconv.FormatInt(int64(*value), 10) }
+
+func (value *int64Value) IsBool() bool { return false }
+
+// RegisteredInt64Param represents a registered 64-bit integer parameter.
+type RegisteredInt64Param struct {
+	registeredParam
+}
+
+// Get returns the 64-bit integer value of the parameter in the given flow.
+func (p RegisteredInt64Param) Get(tf *TF) int64 {
+	value := p.value(tf)
+	return value.Get().(int64)
+}
+
+// Default returns the default value of the parameter.
+// It can be used before the taskflow is run.
+func (p RegisteredInt64Param) Default() int64 {
+	return p.newValue().Get().(int64)
+}
+
 type stringValue string
 
 func (value *stringValue) Set(val string) error {
@@ -170,3 +386,46 @@ func (p RegisteredStringParam) Get(tf *TF) string {
 	value := p.value(tf)
 	return value.Get().(string)
 }
+
+// Default returns the default value of the parameter.
+// It can be used before the taskflow is run.
+func (p RegisteredStringParam) Default() string {
+	return p.newValue().Get().(string)
+}
+
+type transformedStringValue struct {
+	value     string
+	transform func(string) string
+}
+
+func (value *transformedStringValue) Set(s string) error {
+	value.value = value.transform(s)
+	return nil
+}
+
+func (value *transformedStringValue) Get() interface{} { return value.value }
+
+func (value *transformedStringValue) String() string { return value.value }
+
+func (value *transformedStringValue) IsBool() bool { return false }
+
+type stringEnumValue struct {
+	value   string
+	choices []string
+}
+
+func (value *stringEnumValue) Set(s string) error {
+	for _, choice := range value.choices {
+		if s == choice {
+			value.value = s
+			return nil
+		}
+	}
+	return errors.New("must be one of: " + strings.Join(value.choices, ", "))
+}
+
+func (value *stringEnumValue) Get() interface{} { return value.value }
+
+func (value *stringEnumValue) String() string { return value.value }
+
+func (value *stringEnumValue) IsBool() bool { return false }
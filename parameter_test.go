@@ -2,7 +2,10 @@ package goyek_test
 
 import (
 	"context"
+	"errors"
+	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/goyek/goyek"
@@ -31,7 +34,9 @@ func Test_bool_param(t *testing.T) {
 		{defaultValue: false, args: []string{}, exitCode: goyek.CodePass, value: false},
 		{defaultValue: false, args: []string{"-b"}, exitCode: goyek.CodePass, value: true},
 		{defaultValue: true, args: []string{"-b=false"}, exitCode: goyek.CodePass, value: false},
+		{defaultValue: true, args: []string{"-no-b"}, exitCode: goyek.CodePass, value: false},
 
+		{defaultValue: false, args: []string{"-no-b=true"}, exitCode: goyek.CodeInvalidArgs},
 		{defaultValue: false, args: []string{"-b", "false"}, exitCode: goyek.CodeInvalidArgs},
 		{defaultValue: false, args: []string{"-b=maybe"}, exitCode: goyek.CodeInvalidArgs},
 	}
@@ -109,6 +114,72 @@ func Test_int_param_help(t *testing.T) {
 	assertEqual(t, exitCode, 0, "exit code should be OK")
 }
 
+func Test_uint_param(t *testing.T) {
+	tt := []struct {
+		defaultValue uint
+		args         []string
+
+		exitCode int
+		value    uint
+	}{
+		{defaultValue: 1, args: []string{}, exitCode: goyek.CodePass, value: 1},
+		{defaultValue: 1, args: []string{"-u=123"}, exitCode: goyek.CodePass, value: 123},
+		{defaultValue: 1, args: []string{"-u", "0x10"}, exitCode: goyek.CodePass, value: 16},
+
+		{defaultValue: 1, args: []string{"-u=-1"}, exitCode: goyek.CodeInvalidArgs},
+		{defaultValue: 1, args: []string{"-u=abc"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterUintParam(goyek.UintParam{
+				Name:    "u",
+				Default: tc.defaultValue,
+			})
+			var got uint
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_int64_param(t *testing.T) {
+	tt := []struct {
+		defaultValue int64
+		args         []string
+
+		exitCode int
+		value    int64
+	}{
+		{defaultValue: 1, args: []string{}, exitCode: goyek.CodePass, value: 1},
+		{defaultValue: 1, args: []string{"-i=-123"}, exitCode: goyek.CodePass, value: -123},
+		{defaultValue: 1, args: []string{"-i", "9000000000000000000"}, exitCode: goyek.CodePass, value: 9000000000000000000},
+
+		{defaultValue: 1, args: []string{"-i", "9999999999999999999999"}, exitCode: goyek.CodeInvalidArgs},
+		{defaultValue: 1, args: []string{"-i=abc"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterInt64Param(goyek.Int64Param{
+				Name:    "i",
+				Default: tc.defaultValue,
+			})
+			var got int64
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
 func Test_string_param(t *testing.T) {
 	tt := []struct {
 		defaultValue string
@@ -140,6 +211,46 @@ func Test_string_param(t *testing.T) {
 	}
 }
 
+func Test_string_param_transform(t *testing.T) {
+	tt := []struct {
+		env  string
+		args []string
+
+		value string
+	}{
+		{env: "", args: []string{}, value: "default"},
+		{env: "", args: []string{"-s= From-CLI "}, value: "from-cli"},
+		{env: " From-Env", args: []string{}, value: "from-env"},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			if tc.env != "" {
+				os.Setenv("GOYEK_TEST_PARAM", tc.env)
+				defer os.Unsetenv("GOYEK_TEST_PARAM")
+			}
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterStringParam(goyek.StringParam{
+				Name:    "s",
+				Default: "Default",
+				ParamOptions: goyek.ParamOptions{
+					EnvVar: "GOYEK_TEST_PARAM",
+				},
+				Transform: func(s string) string {
+					return strings.ToLower(strings.TrimSpace(s))
+				},
+			})
+			var got string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, goyek.CodePass, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+			assertEqual(t, param.Default(), "default", "default value should be transformed")
+		})
+	}
+}
+
 func Test_string_param_help(t *testing.T) {
 	flow := &goyek.Taskflow{}
 	param := flow.RegisterStringParam(goyek.StringParam{
@@ -150,3 +261,317 @@ func Test_string_param_help(t *testing.T) {
 
 	assertEqual(t, exitCode, 0, "exit code should be OK")
 }
+
+func Test_param_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	boolParam := flow.RegisterBoolParam(goyek.BoolParam{Name: "b", Default: true})
+	intParam := flow.RegisterIntParam(goyek.IntParam{Name: "i", Default: 3})
+	uintParam := flow.RegisterUintParam(goyek.UintParam{Name: "u", Default: 4})
+	int64Param := flow.RegisterInt64Param(goyek.Int64Param{Name: "i64", Default: 5})
+	stringParam := flow.RegisterStringParam(goyek.StringParam{Name: "s", Default: "text"})
+
+	assertEqual(t, boolParam.Default(), true, "bool default")
+	assertEqual(t, intParam.Default(), 3, "int default")
+	assertEqual(t, uintParam.Default(), uint(4), "uint default")
+	assertEqual(t, int64Param.Default(), int64(5), "int64 default")
+	assertEqual(t, stringParam.Default(), "text", "string default")
+	assertEqual(t, flow.VerboseParam().Default(), false, "verbose default")
+}
+
+func Test_required_param(t *testing.T) {
+	tt := []struct {
+		args     []string
+		exitCode int
+	}{
+		{args: []string{}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-s", "abc"}, exitCode: goyek.CodePass},
+		{args: []string{"-s="}, exitCode: goyek.CodePass},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterStringParam(goyek.StringParam{
+				Name: "s",
+				ParamOptions: goyek.ParamOptions{
+					Required: true,
+				},
+			})
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) {}, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+		})
+	}
+}
+
+func Test_param_validate_func(t *testing.T) {
+	tt := []struct {
+		args     []string
+		exitCode int
+	}{
+		{args: []string{}, exitCode: goyek.CodePass},
+		{args: []string{"-port", "8080"}, exitCode: goyek.CodePass},
+		{args: []string{"-port", "0"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-port=65536"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterIntParam(goyek.IntParam{
+				Name:    "port",
+				Default: 80,
+				ParamOptions: goyek.ParamOptions{
+					ValidateFunc: func(s string) error {
+						if port, _ := strconv.Atoi(s); port < 1 || port > 65535 {
+							return errors.New("must be between 1 and 65535")
+						}
+						return nil
+					},
+				},
+			})
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) {}, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+		})
+	}
+}
+
+func Test_env_var_param(t *testing.T) {
+	tt := []struct {
+		env  string
+		args []string
+
+		exitCode int
+		value    string
+	}{
+		{env: "from-env", args: []string{}, exitCode: goyek.CodePass, value: "from-env"},
+		{env: "from-env", args: []string{"-s=from-cli"}, exitCode: goyek.CodePass, value: "from-cli"},
+		{env: "", args: []string{}, exitCode: goyek.CodePass, value: ""},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			os.Setenv("GOYEK_TEST_PARAM", tc.env)
+			defer os.Unsetenv("GOYEK_TEST_PARAM")
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterStringParam(goyek.StringParam{
+				Name:    "s",
+				Default: "default",
+				ParamOptions: goyek.ParamOptions{
+					EnvVar:   "GOYEK_TEST_PARAM",
+					Required: true,
+				},
+			})
+			var got string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_env_var_param_invalid(t *testing.T) {
+	os.Setenv("GOYEK_TEST_PARAM", "abc")
+	defer os.Unsetenv("GOYEK_TEST_PARAM")
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterIntParam(goyek.IntParam{
+		Name: "i",
+		ParamOptions: goyek.ParamOptions{
+			EnvVar: "GOYEK_TEST_PARAM",
+		},
+	})
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) {}, nil)
+
+	assertEqual(t, exitCode, goyek.CodeInvalidArgs, "should fail on invalid value")
+	assertContains(t, sb.String(), "invalid GOYEK_TEST_PARAM environment variable", "should print the variable name")
+}
+
+func Test_env_only_param(t *testing.T) {
+	os.Setenv("GOYEK_TEST_TOKEN", "secret")
+	defer os.Unsetenv("GOYEK_TEST_TOKEN")
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterStringParam(goyek.StringParam{
+		Name: "token",
+		ParamOptions: goyek.ParamOptions{
+			EnvVar:  "GOYEK_TEST_TOKEN",
+			EnvOnly: true,
+		},
+	})
+	var got string
+	flow.Register(goyek.Task{
+		Name:   "task",
+		Usage:  "task using a token",
+		Params: goyek.Params{param},
+		Action: func(tf *goyek.TF) { got = param.Get(tf) },
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+	cliExitCode := flow.Run(context.Background(), "task", "-token=other")
+	sb.Reset()
+	flow.Run(context.Background(), "-h")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, got, "secret", "should set the value from the environment variable")
+	assertEqual(t, cliExitCode, goyek.CodeInvalidArgs, "should not accept the CLI flag")
+	assertTrue(t, !strings.Contains(sb.String(), "-token"), "should not print the flag in usage")
+	assertContains(t, sb.String(), "$GOYEK_TEST_TOKEN", "should print the environment variable in task's usage")
+}
+
+func Test_env_only_param_without_env_var(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	act := func() {
+		flow.RegisterStringParam(goyek.StringParam{
+			Name: "token",
+			ParamOptions: goyek.ParamOptions{
+				EnvOnly: true,
+			},
+		})
+	}
+
+	assertPanics(t, act, "should panic when EnvVar is not set")
+}
+
+func Test_param_flag_collision(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	flow.RegisterBoolParam(goyek.BoolParam{Name: "cache"})
+
+	assertPanics(t, func() { flow.RegisterStringParam(goyek.StringParam{Name: "no-cache"}) }, "should panic when colliding with -no-cache")
+
+	other := &goyek.Taskflow{}
+	other.RegisterStringParam(goyek.StringParam{Name: "no-cache"})
+
+	assertPanics(t, func() { other.RegisterBoolParam(goyek.BoolParam{Name: "cache"}) }, "should panic when -no-cache is already used")
+}
+
+func Test_param_built_in_flag_collision(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+
+	assertPanics(t, func() { flow.RegisterBoolParam(goyek.BoolParam{Name: "help"}) }, "should panic when colliding with -help")
+	assertPanics(t, func() { flow.RegisterIntParam(goyek.IntParam{Name: "v"}) }, "should panic when colliding with -v")
+	assertPanics(t, func() {
+		flow.RegisterStringParam(goyek.StringParam{Name: "dir", ParamOptions: goyek.ParamOptions{Aliases: []string{"wd"}}})
+	}, "should panic when an alias collides with -wd")
+	assertPanics(t, func() { flow.RegisterStringParam(goyek.StringParam{Name: "completion"}) }, "should panic when colliding with -completion")
+	assertPanics(t, func() { flow.RegisterStringParam(goyek.StringParam{Name: "no-v"}) }, "should panic when colliding with -no-v")
+	assertPanics(t, func() { flow.RegisterStringParam(goyek.StringParam{Name: "no-plan"}) }, "should panic when colliding with -no-plan")
+	assertEqual(t, flow.Run(context.Background(), "-h"), goyek.CodePass, "should register the built-in parameters")
+}
+
+func Test_deprecated_param(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterStringParam(goyek.StringParam{
+		Name: "pkgs",
+		ParamOptions: goyek.ParamOptions{
+			Deprecated: "use -pkg instead",
+		},
+	})
+	var got string
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, []string{"-pkgs=./..."})
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, got, "./...", "should set the value")
+	assertContains(t, sb.String(), "warning: flag -pkgs is deprecated: use -pkg instead", "should print the warning")
+}
+
+func Test_deprecated_param_alias(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterStringParam(goyek.StringParam{
+		Name: "pkg",
+		ParamOptions: goyek.ParamOptions{
+			Deprecated: "use -packages instead",
+			Aliases:    []string{"p"},
+		},
+	})
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) {}, []string{"-p", "./..."})
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertContains(t, sb.String(), "warning: flag -p is deprecated: use -packages instead", "should print the flag used in the arguments")
+}
+
+func Test_param_aliases(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterIntParam(goyek.IntParam{
+		Name:  "concurrency",
+		Usage: "Number of workers",
+		ParamOptions: goyek.ParamOptions{
+			Aliases: []string{"workers"},
+		},
+	})
+	var got int
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, []string{"-concurrency=2", "-workers", "3"})
+	flow.Run(context.Background(), "-h")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, got, 3, "should set the value via the last flag")
+	assertContains(t, sb.String(), "-concurrency, -workers", "should print the aliases in usage")
+	assertPanics(t, func() { flow.RegisterBoolParam(goyek.BoolParam{Name: "workers"}) }, "should panic when colliding with an alias")
+}
+
+func Test_string_enum_param(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		exitCode int
+		value    string
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, value: "dev"},
+		{args: []string{"-env=prod"}, exitCode: goyek.CodePass, value: "prod"},
+		{args: []string{"-env", "staging"}, exitCode: goyek.CodePass, value: "staging"},
+
+		{args: []string{"-env=test"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			param := flow.RegisterStringEnumParam(goyek.StringEnumParam{
+				Name:    "env",
+				Default: "dev",
+				Choices: []string{"dev", "staging", "prod"},
+			})
+			var got string
+			exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) { got = param.Get(tf) }, tc.args)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, got, tc.value, "value should match")
+		})
+	}
+}
+
+func Test_string_enum_param_help(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterStringEnumParam(goyek.StringEnumParam{
+		Name:    "env",
+		Default: "dev",
+		Choices: []string{"dev", "prod"},
+	})
+	exitCode := runTaskflowWith(flow, param, func(tf *goyek.TF) {}, []string{"-h"})
+
+	assertEqual(t, exitCode, 0, "exit code should be OK")
+	assertContains(t, sb.String(), "Default: dev (one of: dev, prod)", "should print the choices")
+}
+
+func Test_string_enum_param_invalid_default(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	act := func() {
+		flow.RegisterStringEnumParam(goyek.StringEnumParam{
+			Name:    "env",
+			Default: "test",
+			Choices: []string{"dev", "prod"},
+		})
+	}
+
+	assertPanics(t, act, "should panic when the default value is not one of the choices")
+}
@@ -0,0 +1,69 @@
+package goyek
+
+import (
+	"bytes"
+	"io"
+)
+
+// PrefixWriter prepends a prefix to every line written to the underlying writer.
+type PrefixWriter struct {
+	lines *lineWriter
+}
+
+// NewPrefixWriter returns a writer which prepends the prefix to every line written to w,
+// e.g. to distinguish the output of a process started by a task's action.
+// Incomplete lines are buffered until a newline is written or Flush is called.
+func NewPrefixWriter(prefix string, w io.Writer) *PrefixWriter {
+	return &PrefixWriter{lines: newPrefixWriter(w, prefix)}
+}
+
+// Write writes the complete lines of p, each prepended with the prefix, to the underlying writer.
+func (w *PrefixWriter) Write(p []byte) (int, error) {
+	return w.lines.Write(p)
+}
+
+// Flush writes the buffered incomplete line followed by a newline.
+func (w *PrefixWriter) Flush() error {
+	return w.lines.Flush()
+}
+
+// lineWriter calls writeLine for every line written to it.
+// Incomplete lines are buffered until a newline is written or Flush is called.
+type lineWriter struct {
+	writeLine func(line []byte) error // the line includes the trailing newline
+	buf       []byte
+}
+
+// newPrefixWriter returns a writer which prepends the prefix to every line written to w.
+func newPrefixWriter(w io.Writer, prefix string) *lineWriter {
+	return &lineWriter{
+		writeLine: func(line []byte) error {
+			_, err := w.Write(append([]byte(prefix), line...))
+			return err
+		},
+	}
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush writes the buffered incomplete line followed by a newline.
+func (w *lineWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := append(w.buf, '\n')
+	w.buf = nil
+	return w.writeLine(line)
+}
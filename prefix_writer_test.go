@@ -0,0 +1,23 @@
+package goyek_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_PrefixWriter(t *testing.T) {
+	sb := &strings.Builder{}
+	w := goyek.NewPrefixWriter("[cmd] ", sb)
+
+	io.WriteString(w, "first\nsec") //nolint:errcheck // test
+	beforeNewline := sb.String()
+	io.WriteString(w, "ond\nthird") //nolint:errcheck // test
+	err := w.Flush()
+
+	requireEqual(t, err, nil, "should flush")
+	assertEqual(t, beforeNewline, "[cmd] first\n", "should buffer the incomplete line")
+	assertEqual(t, sb.String(), "[cmd] first\n[cmd] second\n[cmd] third\n", "should prefix every line")
+}
@@ -0,0 +1,48 @@
+package goyek
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// RunAction runs only the action of the task with the given name, without its dependencies.
+// The action uses a fresh TF with the default values of the task's parameters
+// and its output is discarded. The returned result's Duration covers only the action,
+// so RunAction can be used to benchmark the action, see the goyektest package.
+// An error is returned if the task is not registered or has no action.
+func (f *Taskflow) RunAction(ctx context.Context, taskName string) (TaskResult, error) {
+	if f.parent != nil {
+		return f.parent.RunAction(ctx, taskName)
+	}
+	task, ok := f.tasks[taskName]
+	if !ok {
+		return TaskResult{}, fmt.Errorf("unknown task: %s", taskName)
+	}
+	if task.Action == nil {
+		return TaskResult{}, fmt.Errorf("%s task has no action", taskName)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	paramValues := map[string]ParamValue{
+		f.VerbosityParam().Name(): f.params[f.VerbosityParam().Name()].newValue(),
+	}
+	for _, param := range task.Params {
+		paramValues[param.Name()] = f.params[param.Name()].newValue()
+	}
+	r := runner{
+		Ctx:         ctx,
+		TaskName:    task.Name,
+		Output:      ioutil.Discard,
+		ParamValues: paramValues,
+		Store:       &sync.Map{},
+	}
+	start := time.Now()
+	result := r.Run(taskAction(task))
+	end := time.Now()
+	return TaskResult{Name: task.Name, Status: result.Status(), Duration: result.Duration(), Start: start, End: end}, nil
+}
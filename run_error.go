@@ -0,0 +1,33 @@
+package goyek
+
+import "strconv"
+
+// RunError records an unsuccessful taskflow run.
+type RunError struct {
+	Code int // the exit code, e.g. CodeFail or CodeInvalidArgs
+}
+
+func (e *RunError) Error() string {
+	return "goyek: run failed with exit code " + strconv.Itoa(e.Code)
+}
+
+// TaskError records a failure or an interruption of a task.
+// It is returned by Taskflow.Execute when a task fails or is interrupted.
+type TaskError struct {
+	TaskName string // the name of the failed task
+	ExitCode int    // the exit code of the run, i.e. CodeFail
+	Cause    error  // the cause of the interruption, e.g. context.Canceled
+}
+
+func (e *TaskError) Error() string {
+	msg := "task failed: " + e.TaskName
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the cause of the failure.
+func (e *TaskError) Unwrap() error {
+	return e.Cause
+}
@@ -0,0 +1,21 @@
+package goyek_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_RunError(t *testing.T) {
+	err := &goyek.RunError{Code: goyek.CodeInvalidArgs}
+
+	assertEqual(t, err.Error(), "goyek: run failed with exit code 2", "should have proper message")
+}
+
+func Test_TaskError(t *testing.T) {
+	err := &goyek.TaskError{TaskName: "lint", ExitCode: goyek.CodeFail, Cause: errors.New("exit status 1")}
+
+	assertEqual(t, err.Error(), "task failed: lint: exit status 1", "should have proper message")
+	assertEqual(t, err.Unwrap(), err.Cause, "should unwrap the cause")
+}
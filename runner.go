@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"runtime/debug"
+	"sync"
 	"time"
 )
 
@@ -13,6 +14,8 @@ type runner struct {
 	TaskName    string
 	Output      io.Writer
 	ParamValues map[string]ParamValue
+	Store       *sync.Map // values shared between the tasks of a run
+	Progress    io.Writer // output for the progress reported via TF.Progress, Output if nil
 }
 
 // runResult contains the results of a Action run.
@@ -39,6 +42,17 @@ func (r runResult) Duration() time.Duration {
 	return r.duration
 }
 
+// Status returns "FAIL" if the action failed, "SKIP" if it was skipped, or "PASS" otherwise.
+func (r runResult) Status() string {
+	switch {
+	case r.failed:
+		return "FAIL"
+	case r.skipped:
+		return "SKIP"
+	}
+	return "PASS"
+}
+
 // Run runs the action.
 func (r runner) Run(action func(tf *TF)) runResult {
 	finished := make(chan runResult)
@@ -49,21 +63,35 @@ func (r runner) Run(action func(tf *TF)) runResult {
 			name:        r.TaskName,
 			writer:      writer,
 			paramValues: r.ParamValues,
+			store:       r.Store,
+			progressOut: r.Progress,
+			tfState:     &tfState{},
+		}
+		if tf.progressOut == nil {
+			tf.progressOut = writer
 		}
 		from := time.Now()
 		defer func() {
-			if r := recover(); r != nil {
-				tf.Errorf("panic: %v", r)
-				tf.Log(string(debug.Stack()))
-			}
 			result := runResult{
-				failed:   tf.failed,
-				skipped:  tf.skipped,
+				failed:   tf.Failed(),
+				skipped:  tf.Skipped(),
 				duration: time.Since(from),
 			}
 			finished <- result
 		}()
+		defer tf.runCleanups()
+		defer tf.stopProgress()
+		defer recoverPanic(tf)
 		action(tf)
 	}()
 	return <-finished
 }
+
+// recoverPanic marks the task as failed if it panicked.
+// It must be called using defer.
+func recoverPanic(tf *TF) {
+	if r := recover(); r != nil {
+		tf.Errorf("panic: %v", r)
+		tf.Log(string(debug.Stack()))
+	}
+}
@@ -0,0 +1,63 @@
+package goyek
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// streamingWriter buffers the output until stream is called.
+// Afterwards, it writes the buffered output and all subsequent writes to out,
+// with each line prefixed by prefix if it is not empty.
+type streamingWriter struct {
+	out    io.Writer
+	prefix string
+
+	mtx       sync.Mutex
+	buf       strings.Builder
+	streamOut io.Writer   // the writer used after stream is called
+	lines     *lineWriter // the prefixing writer used after stream is called
+	closed    bool
+}
+
+func (w *streamingWriter) Write(p []byte) (int, error) {
+	defer func() { w.mtx.Unlock() }()
+	w.mtx.Lock()
+	if w.streamOut != nil {
+		return w.streamOut.Write(p)
+	}
+	return w.buf.Write(p)
+}
+
+// stream writes the buffered output to out and makes the subsequent writes go directly to out.
+// It has no effect after close is called.
+func (w *streamingWriter) stream() {
+	defer func() { w.mtx.Unlock() }()
+	w.mtx.Lock()
+	if w.streamOut != nil || w.closed {
+		return
+	}
+	w.streamOut = w.out
+	if w.prefix != "" {
+		w.lines = newPrefixWriter(w.out, w.prefix)
+		w.streamOut = w.lines
+	}
+	io.WriteString(w.streamOut, w.buf.String()) //nolint // not checking errors when writing to output
+	w.buf.Reset()
+}
+
+// close writes the buffered output to out, without the prefix, if flush is true and discards it otherwise.
+// If the output is streamed, the incomplete line is written.
+// After close returns, nothing more is written to out.
+func (w *streamingWriter) close(flush bool) {
+	defer func() { w.mtx.Unlock() }()
+	w.mtx.Lock()
+	if flush && w.streamOut == nil {
+		io.WriteString(w.out, w.buf.String()) //nolint // not checking errors when writing to output
+	}
+	if w.lines != nil {
+		w.lines.Flush() //nolint // not checking errors when writing to output
+	}
+	w.buf.Reset()
+	w.closed = true
+}
@@ -0,0 +1,64 @@
+package goyek
+
+// SubSeparator separates the sub-flow's prefix from the task's name.
+const SubSeparator = "/"
+
+// Sub returns a sub-flow which registers tasks in the taskflow
+// with the names prefixed by the given prefix followed by SubSeparator, e.g. "backend/build".
+// The sub-flow shares the parameters and the tasks with the taskflow.
+// Running the sub-flow runs the taskflow, and the fields of the sub-flow are ignored.
+// It panics if the prefix is not a valid task name.
+func (f *Taskflow) Sub(prefix string) *Taskflow {
+	if !taskNameRegex.MatchString(prefix) {
+		panic("sub-flow prefix must match TaskNamePattern")
+	}
+	if f.params == nil {
+		f.params = make(map[string]registeredParam)
+	}
+	if f.tasks == nil {
+		f.tasks = make(map[string]Task)
+	}
+	return &Taskflow{
+		Output: f.Output,
+		params: f.params,
+		tasks:  f.tasks,
+		parent: f,
+		prefix: prefix + SubSeparator,
+	}
+}
+
+// registerInParent registers the task with the prefixed name in the parent taskflow.
+func (f *Taskflow) registerInParent(task Task) RegisteredTask {
+	if !taskNameRegex.MatchString(task.Name) {
+		panic("task name must match TaskNamePattern")
+	}
+	task.Name = f.prefix + task.Name
+	return f.parent.Register(task)
+}
+
+// mergeInParent merges the other taskflow into the parent taskflow
+// with the names of the other taskflow's tasks, including their dependencies, prefixed.
+func (f *Taskflow) mergeInParent(other *Taskflow) error {
+	prefixed := &Taskflow{
+		params:    other.params,
+		tasks:     make(map[string]Task, len(other.tasks)),
+		verbosity: other.verbosity,
+		workDir:   other.workDir,
+		plan:      other.plan,
+	}
+	for name, task := range other.tasks {
+		task = task.clone()
+		task.Name = f.prefix + name
+		task.Deps = prefixDeps(f.prefix, task.Deps)
+		task.OnFailureDeps = prefixDeps(f.prefix, task.OnFailureDeps)
+		prefixed.tasks[task.Name] = task
+	}
+	return f.parent.Merge(prefixed)
+}
+
+func prefixDeps(prefix string, deps Deps) Deps {
+	for i, dep := range deps {
+		deps[i] = RegisteredTask{name: prefix + dep.name}
+	}
+	return deps
+}
@@ -0,0 +1,94 @@
+package goyek_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_Sub(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	backend := flow.Sub("backend")
+	param := backend.RegisterStringParam(goyek.StringParam{Name: "pkg", Default: "./..."})
+	var executed []string
+	var gotParam string
+	build := backend.Register(goyek.Task{
+		Name:   "build",
+		Params: goyek.Params{param},
+		Action: func(tf *goyek.TF) {
+			executed = append(executed, tf.Name())
+			gotParam = param.Get(tf)
+		},
+	})
+	backend.Register(goyek.Task{
+		Name:   "test",
+		Deps:   goyek.Deps{build},
+		Action: func(tf *goyek.TF) { executed = append(executed, tf.Name()) },
+	})
+	flow.Register(goyek.Task{
+		Name:   "all",
+		Deps:   goyek.Deps{build},
+		Action: func(tf *goyek.TF) { executed = append(executed, tf.Name()) },
+	})
+
+	exitCode := backend.Run(context.Background(), "backend/test", "all", "-pkg", "./cmd/...")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, executed, []string{"backend/build", "backend/test", "all"}, "should run the prefixed tasks")
+	assertEqual(t, gotParam, "./cmd/...", "should share the parameters")
+}
+
+func Test_Sub_nested(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	var got string
+	flow.Sub("services").Sub("api").Register(goyek.Task{
+		Name:   "build",
+		Action: func(tf *goyek.TF) { got = tf.Name() },
+	})
+
+	exitCode := flow.Run(context.Background(), "services/api/build")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, got, "services/api/build", "should prefix the task's name with all prefixes")
+}
+
+func Test_Sub_Merge(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	other := &goyek.Taskflow{Output: &strings.Builder{}}
+	var executed []string
+	build := other.Register(goyek.Task{
+		Name:   "build",
+		Action: func(tf *goyek.TF) { executed = append(executed, tf.Name()) },
+	})
+	other.Register(goyek.Task{
+		Name:   "test",
+		Deps:   goyek.Deps{build},
+		Action: func(tf *goyek.TF) { executed = append(executed, tf.Name()) },
+	})
+
+	err := flow.Sub("backend").Merge(other)
+	exitCode := flow.Run(context.Background(), "backend/test")
+	otherExitCode := other.Run(context.Background(), "test")
+
+	requireEqual(t, err, nil, "should merge")
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, otherExitCode, goyek.CodePass, "should not change the other taskflow")
+	assertEqual(t, executed, []string{"backend/build", "backend/test", "build", "test"}, "should run the prefixed tasks")
+}
+
+func Test_Sub_Clone(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	backend := flow.Sub("backend")
+	build := backend.Register(goyek.Task{Name: "build"})
+
+	clone := backend.Clone()
+	clone.Register(goyek.Task{Name: "test", Deps: goyek.Deps{build}})
+	cloneExitCode := clone.Run(context.Background(), "backend/test")
+	exitCode := flow.Run(context.Background(), "backend/test")
+
+	assertEqual(t, cloneExitCode, goyek.CodePass, "should run the task registered in the clone")
+	assertEqual(t, exitCode, goyek.CodeInvalidArgs, "should not register the task in the original")
+	backend.Register(goyek.Task{Name: "test"}) // panics if the task was registered in the original
+}
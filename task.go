@@ -1,5 +1,10 @@
 package goyek
 
+import (
+	"context"
+	"time"
+)
+
 // Task represents a named task that can be registered.
 // It can consist of a action (function that will be called when task is run),
 // dependencies (tasks which has to be run before this one)
@@ -18,13 +23,98 @@ type Task struct {
 	// for a list of dependencies.
 	Action func(tf *TF)
 
+	// SkipIf is called after the dependencies are run.
+	// If it returns true, the task is skipped without calling its action.
+	SkipIf func(ctx context.Context) bool
+
+	// Condition is called after the dependencies are run.
+	// If it returns false, the task is skipped without calling its action.
+	// Unlike SkipIf, it can read the values of the task's parameters using the TF.
+	Condition func(tf *TF) bool
+
+	// WorkDir is the working directory in which the action is run.
+	// A relative path is resolved against the working directory at the task's start.
+	// The previous working directory is restored after the action returns.
+	// As the working directory is global for the process,
+	// it must not be changed concurrently by other goroutines.
+	WorkDir string
+
+	// Environment contains the environment variables which are set when the action is run.
+	// The previous values are restored after the action returns.
+	// As the environment is global for the process,
+	// it must not be changed concurrently by other goroutines.
+	Environment map[string]string
+
+	// Cacheable makes the task skipped by Taskflow.RunOnce
+	// if it passed before with the same parameter values and input files.
+	Cacheable bool
+
+	// Inputs are glob patterns of the files which invalidate the cache of a Cacheable task when changed.
+	// A directory matched by a pattern includes all files within it.
+	// Relative patterns are resolved against WorkDir like the action's paths.
+	Inputs []string
+
+	// Outputs are the paths of the files created by a Cacheable task.
+	// The task is not skipped by Taskflow.RunOnce if any of them does not exist.
+	// Relative paths are resolved against WorkDir like the action's paths.
+	Outputs []string
+
+	// RunAlways makes the task run each time it is encountered
+	// instead of at most once per taskflow run.
+	RunAlways bool
+
+	// OnFailureDeps lists all registered tasks that are run after this task fails.
+	// They are not run if the task passes and their results do not affect the taskflow's result.
+	OnFailureDeps Deps
+
+	// Labels are key-value pairs which can be used to select the tasks to run.
+	// Use the -label=key=value CLI flag to run only the tasks having the label.
+	Labels map[string]string
+
 	// Deps lists all registered tasks that need to be run before this task is executed.
 	Deps Deps
 
+	// DependencyOrder is the order in which the dependencies are run.
+	// By default, DependencyOrderStable is used.
+	DependencyOrder string
+
 	// Params is a list of registered parameters that the action may need during executions.
 	// Not all parameters need to be queried during execution, yet accessing a parameter
 	// that was not registered will fail the task.
 	Params Params
+
+	index int // the order in which the task was registered
+}
+
+// Orders in which the dependencies of a task are run.
+const (
+	DependencyOrderStable       = "stable"       // the order of Task.Deps
+	DependencyOrderAlpha        = "alpha"        // the alphabetical order of the tasks' names
+	DependencyOrderRegistration = "registration" // the order in which the tasks were registered
+)
+
+// clone returns a copy of the task which does not share the slices and maps.
+func (task Task) clone() Task {
+	task.Deps = append(Deps(nil), task.Deps...)
+	task.OnFailureDeps = append(Deps(nil), task.OnFailureDeps...)
+	task.Params = append(Params(nil), task.Params...)
+	task.Inputs = append([]string(nil), task.Inputs...)
+	task.Outputs = append([]string(nil), task.Outputs...)
+	if task.Labels != nil {
+		labels := make(map[string]string, len(task.Labels))
+		for key, value := range task.Labels {
+			labels[key] = value
+		}
+		task.Labels = labels
+	}
+	if task.Environment != nil {
+		environment := make(map[string]string, len(task.Environment))
+		for key, value := range task.Environment {
+			environment[key] = value
+		}
+		task.Environment = environment
+	}
+	return task
 }
 
 // Deps represents a collection of registered Tasks.
@@ -32,3 +122,18 @@ type Deps []RegisteredTask
 
 // Params represents a collection of registered Params.
 type Params []RegisteredParam
+
+// TaskResult represents the result of a task run.
+type TaskResult struct {
+	Name     string        // the task's name
+	Status   string        // "PASS", "FAIL" or "SKIP"
+	Duration time.Duration // the duration of the task's action
+	Start    time.Time     // the time when the task's action started
+	End      time.Time     // the time when the task's action ended
+}
+
+// RunStats summarizes a taskflow run.
+type RunStats struct {
+	Tasks []TaskResult  // the results of the tasks' actions in execution order
+	Total time.Duration // the duration of running the tasks
+}
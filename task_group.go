@@ -0,0 +1,54 @@
+package goyek
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskGroupSeparator separates the group's name from the task's name.
+const TaskGroupSeparator = ":"
+
+// TaskGroup registers tasks within a namespace.
+// The names of the tasks registered via the group are prefixed
+// with the group's name followed by TaskGroupSeparator.
+type TaskGroup struct {
+	flow   *Taskflow
+	prefix string
+}
+
+// RegisterGroup returns a TaskGroup which registers tasks in the taskflow
+// with the names prefixed by the given group name.
+// It panics if the group name is not a valid task name.
+func (f *Taskflow) RegisterGroup(name string) *TaskGroup {
+	if !taskNameRegex.MatchString(name) || strings.Contains(name, TaskGroupSeparator) {
+		panic("group name must match TaskNamePattern and must not contain TaskGroupSeparator")
+	}
+	return &TaskGroup{flow: f, prefix: name + TaskGroupSeparator}
+}
+
+// Register registers the task with the name prefixed by the group's name.
+// It panics in case of any error.
+func (g *TaskGroup) Register(task Task) RegisteredTask {
+	task.Name = g.prefix + task.Name
+	return g.flow.Register(task)
+}
+
+// Dep returns the task, which can be registered in any group of the taskflow,
+// so that it can be used as a dependency of the tasks in this group.
+// It panics if the task is not registered.
+func (g *TaskGroup) Dep(task RegisteredTask) RegisteredTask {
+	if !g.flow.isRegistered(task.name) {
+		panic(fmt.Sprintf("invalid dependency %s", task.name))
+	}
+	return task
+}
+
+// taskGroupName returns the name of the group of the task,
+// or an empty string if the task does not belong to any group.
+func taskGroupName(taskName string) string {
+	i := strings.LastIndex(taskName, TaskGroupSeparator)
+	if i < 0 {
+		return ""
+	}
+	return taskName[:i]
+}
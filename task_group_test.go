@@ -0,0 +1,52 @@
+package goyek_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_RegisterGroup(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	var got []string
+	build := flow.Register(goyek.Task{
+		Name:  "build",
+		Usage: "build",
+		Action: func(tf *goyek.TF) {
+			got = append(got, tf.Name())
+		},
+	})
+	lint := flow.RegisterGroup("lint")
+	lint.Register(goyek.Task{
+		Name:  "go",
+		Usage: "lint Go code",
+		Deps:  goyek.Deps{lint.Dep(build)},
+		Action: func(tf *goyek.TF) {
+			got = append(got, tf.Name())
+		},
+	})
+	flow.Register(goyek.Task{Name: "test", Usage: "test"})
+
+	exitCode := flow.Run(context.Background(), "lint:go")
+	flow.Run(context.Background(), "-h")
+
+	assertEqual(t, exitCode, 0, "should pass")
+	assertEqual(t, got, []string{"build", "lint:go"}, "should run the task with a prefixed name")
+	assertContains(t, sb.String(), "  build      build\n  test       test\n  lint:go    lint Go code\n", "should list the group's tasks after other tasks")
+}
+
+func Test_RegisterGroup_invalid_name(t *testing.T) {
+	flow := &goyek.Taskflow{}
+
+	assertPanics(t, func() { flow.RegisterGroup("a:b") }, "should panic when the group name contains the separator")
+}
+
+func Test_TaskGroup_Dep_not_registered(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	group := flow.RegisterGroup("group")
+
+	assertPanics(t, func() { group.Dep(goyek.RegisteredTask{}) }, "should panic when the task is not registered")
+}
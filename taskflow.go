@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -17,18 +22,81 @@ const (
 	CodeInvalidArgs = 2
 )
 
+// DefaultUsageWidth is the default width at which the tasks' usage is wrapped.
+const DefaultUsageWidth = 60
+
 // Taskflow is the root type of the package.
 // Use Register methods to register all tasks
 // and Run or Main method to execute provided tasks.
 type Taskflow struct {
 	Output io.Writer // output where text is printed; os.Stdout by default
 
-	DefaultTask RegisteredTask // task which is run when non is explicitly provided
+	// Color controls coloring the status of the tasks in the output.
+	// If nil, then colors are used only when the output is a terminal.
+	Color *bool
+
+	DefaultTask  RegisteredTask   // task which is run when non is explicitly provided
+	DefaultTasks []RegisteredTask // tasks which are run in order when non is explicitly provided; takes precedence over DefaultTask
+
+	// Quiet makes the taskflow print only the summary of the run, the output of the failed tasks and the errors.
+	// In particular, the progress reported by passed and skipped tasks, the Gantt chart,
+	// the on-failure markers and the deprecation warnings are not printed.
+	// It has no effect in verbose mode.
+	Quiet bool
+
+	// StreamAfter is the duration after which the output of a running task is streamed
+	// instead of being printed only if the task fails. The streamed lines are prefixed with the task's name.
+	// If it is not positive, then the output is not streamed. It has no effect in verbose mode.
+	StreamAfter time.Duration
+
+	// PrefixOutput makes each line printed by a task's action prefixed with the task's name,
+	// e.g. "[build] compiling".
+	PrefixOutput bool
+
+	// PrintGantt makes the taskflow print a text Gantt chart
+	// of the tasks' execution times after the tasks are run.
+	PrintGantt bool
+
+	// MaxFailures is the number of failed tasks provided via CLI after which the run is stopped.
+	// The other provided tasks are run until it is reached.
+	// If it is not positive, then the run is stopped after the first failure.
+	MaxFailures int
+
+	// UsageWidth is the width at which the tasks' usage is wrapped in the usage output.
+	// If it is not positive, then DefaultUsageWidth is used.
+	UsageWidth int
+
+	// JSONLines makes the taskflow print the tasks' events as JSON objects, one per line,
+	// instead of the human-readable output. The events are:
+	//  {"event":"start","task":"name","time":"2006-01-02T15:04:05Z07:00"}
+	//  {"event":"log","task":"name","message":"..."}
+	//  {"event":"end","task":"name","status":"PASS","durationMs":123}
+	JSONLines bool
+
+	// TraceFile is the path of the file to which the runtime execution trace
+	// of the tasks' run is written. It can be viewed using "go tool trace".
+	TraceFile string
+
+	// ReportJUnit is the path of the file to which a JUnit XML report is written
+	// after the tasks are run. Each executed task is reported as a test case.
+	ReportJUnit string
+
+	OnTaskStart func(name string)                    // called before a task's action is run
+	OnTaskEnd   func(name string, result TaskResult) // called after a task's action is run
 
-	verbose *RegisteredBoolParam   // when enabled, then the whole output will be always streamed
-	workDir *RegisteredStringParam // sets the working directory
-	params  map[string]registeredParam
-	tasks   map[string]Task
+	verbosity *RegisteredIntParam    // controls which output is streamed
+	workDir   *RegisteredStringParam // sets the working directory
+	plan      *RegisteredBoolParam   // when enabled, then the tasks are printed instead of being run
+	params    map[string]registeredParam
+	tasks     map[string]Task
+	listeners []ExecutionListener
+	wrappers  []actionWrapper
+	running   *flowRunner // the runner of the ongoing run
+	parent    *Taskflow   // the taskflow of a sub-flow
+	prefix    string      // the prefix of the sub-flow's tasks
+	stats     RunStats    // the summary of the most recent run
+	taskErr   *TaskError  // the task failure of the most recent run
+	lastArgs  []string    // the arguments of the most recent run
 }
 
 // RegisteredTask represents a task that has been registered to a Taskflow.
@@ -37,44 +105,101 @@ type RegisteredTask struct {
 	name string
 }
 
-// VerboseParam returns the out-of-the-box verbose parameter which controls the output behavior.
-func (f *Taskflow) VerboseParam() RegisteredBoolParam {
-	if f.verbose == nil {
-		param := f.RegisterBoolParam(BoolParam{
-			Name:  "v",
-			Usage: "Verbose: log all tasks as they are run.",
-		})
-		f.verbose = &param
+// VerbosityParam returns the out-of-the-box verbosity parameter which controls the output behavior.
+// Level 0 streams only the output of failed tasks, level 1 streams the output of all tasks
+// and level 2 additionally prints the parameter values of each task.
+// The -v flag sets level 1 and -v=2 or -v 2 sets level 2.
+func (f *Taskflow) VerbosityParam() RegisteredIntParam {
+	if f.parent != nil {
+		return f.parent.VerbosityParam()
+	}
+	if f.verbosity == nil {
+		regParam := verbosityParam()
+		f.addParam(regParam)
+		param := RegisteredIntParam{regParam}
+		f.verbosity = &param
 	}
 
-	return *f.verbose
+	return *f.verbosity
+}
+
+// VerboseParam returns the out-of-the-box verbose parameter which controls the output behavior.
+// Its value is true if the verbosity level is at least 1. See VerbosityParam.
+func (f *Taskflow) VerboseParam() RegisteredVerboseParam {
+	return RegisteredVerboseParam{f.VerbosityParam().registeredParam}
 }
 
 // WorkDirParam returns the out-of-the-box working directory parameter which controls the working directory.
 func (f *Taskflow) WorkDirParam() RegisteredStringParam {
+	if f.parent != nil {
+		return f.parent.WorkDirParam()
+	}
 	if f.workDir == nil {
-		param := f.RegisterStringParam(StringParam{
-			Name:    "wd",
-			Usage:   "Working directory: set the working directory.",
-			Default: ".",
-		})
+		regParam := workDirParam()
+		f.addParam(regParam)
+		param := RegisteredStringParam{regParam}
 		f.workDir = &param
 	}
 
 	return *f.workDir
 }
 
+// PlanParam returns the out-of-the-box plan parameter which makes the taskflow
+// print the tasks in execution order instead of running them.
+func (f *Taskflow) PlanParam() RegisteredBoolParam {
+	if f.parent != nil {
+		return f.parent.PlanParam()
+	}
+	if f.plan == nil {
+		regParam := planParam()
+		f.addParam(regParam)
+		param := RegisteredBoolParam{regParam}
+		f.plan = &param
+	}
+
+	return *f.plan
+}
+
+func verbosityParam() registeredParam {
+	return registeredParam{
+		name:  "v",
+		usage: "Verbose: log all tasks as they are run; -v=2 also logs parameter values.",
+		newValue: func() ParamValue {
+			var value verbosityValue
+			return &value
+		},
+	}
+}
+
+func workDirParam() registeredParam {
+	return registeredParam{
+		name:  "wd",
+		usage: "Working directory: set the working directory.",
+		newValue: func() ParamValue {
+			value := stringValue(".")
+			return &value
+		},
+	}
+}
+
+func planParam() registeredParam {
+	return registeredParam{
+		name:  "plan",
+		usage: "Plan: print the tasks in execution order without running them.",
+		newValue: func() ParamValue {
+			var value boolValue
+			return &value
+		},
+	}
+}
+
 // RegisterValueParam registers a generic parameter that is defined by the calling code.
 // Use this variant in case the primitive-specific implementations cannot cover the parameter.
 //
 // The value is provided via a factory function since Taskflow could be executed multiple times,
 // requiring a new Value instance each time.
 func (f *Taskflow) RegisterValueParam(p ValueParam) RegisteredValueParam {
-	regParam := registeredParam{
-		name:     p.Name,
-		usage:    p.Usage,
-		newValue: p.NewValue,
-	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, p.NewValue)
 	f.registerParam(regParam)
 	return RegisteredValueParam{regParam}
 }
@@ -85,12 +210,9 @@ func (f *Taskflow) RegisterBoolParam(p BoolParam) RegisteredBoolParam {
 		value := boolValue(p.Default)
 		return &value
 	}
-	f.registerParam(registeredParam{
-		name:     p.Name,
-		usage:    p.Usage,
-		newValue: valGetter,
-	})
-	return RegisteredBoolParam{registeredParam{name: p.Name}}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredBoolParam{regParam}
 }
 
 // RegisterIntParam registers an integer parameter.
@@ -99,26 +221,59 @@ func (f *Taskflow) RegisterIntParam(p IntParam) RegisteredIntParam {
 		value := intValue(p.Default)
 		return &value
 	}
-	regParam := registeredParam{
-		name:     p.Name,
-		usage:    p.Usage,
-		newValue: valGetter,
-	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
 	f.registerParam(regParam)
 	return RegisteredIntParam{regParam}
 }
 
+// RegisterUintParam registers an unsigned integer parameter.
+func (f *Taskflow) RegisterUintParam(p UintParam) RegisteredUintParam {
+	valGetter := func() ParamValue {
+		value := uintValue(p.Default)
+		return &value
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredUintParam{regParam}
+}
+
+// RegisterInt64Param registers a 64-bit integer parameter.
+func (f *Taskflow) RegisterInt64Param(p Int64Param) RegisteredInt64Param {
+	valGetter := func() ParamValue {
+		value := int64Value(p.Default)
+		return &value
+	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredInt64Param{regParam}
+}
+
 // RegisterStringParam registers a string parameter.
 func (f *Taskflow) RegisterStringParam(p StringParam) RegisteredStringParam {
 	valGetter := func() ParamValue {
+		if p.Transform != nil {
+			return &transformedStringValue{value: p.Transform(p.Default), transform: p.Transform}
+		}
 		value := stringValue(p.Default)
 		return &value
 	}
-	regParam := registeredParam{
-		name:     p.Name,
-		usage:    p.Usage,
-		newValue: valGetter,
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	f.registerParam(regParam)
+	return RegisteredStringParam{regParam}
+}
+
+// RegisterStringEnumParam registers a string parameter restricted to a fixed set of values.
+// It panics if the default value is not one of the choices.
+func (f *Taskflow) RegisterStringEnumParam(p StringEnumParam) RegisteredStringParam {
+	choices := append([]string(nil), p.Choices...)
+	valGetter := func() ParamValue {
+		return &stringEnumValue{value: p.Default, choices: choices}
+	}
+	if err := valGetter().Set(p.Default); err != nil {
+		panic(fmt.Sprintf("%s parameter has invalid default value: %v", p.Name, err))
 	}
+	regParam := p.ParamOptions.registeredParam(p.Name, p.Usage, valGetter)
+	regParam.hint = "one of: " + strings.Join(choices, ", ")
 	f.registerParam(regParam)
 	return RegisteredStringParam{regParam}
 }
@@ -128,15 +283,47 @@ const ParamNamePattern = "^[a-zA-Z0-9][a-zA-Z0-9_-]*$"
 
 var paramNameRegex = regexp.MustCompile(ParamNamePattern)
 
+// builtInFlags are the names of the CLI flags, without the leading dash, handled by the taskflow itself.
+// They include all flags of the out-of-the-box parameters, e.g. -no-plan.
+var builtInFlags = append(
+	[]string{"h", "help", listFlag[1:], labelFlag[1:], completionFlag[1:]},
+	paramFlags(verbosityParam(), workDirParam(), planParam())...,
+)
+
+// paramFlags returns the CLI flags of the parameters.
+func paramFlags(params ...registeredParam) []string {
+	var flags []string
+	for _, p := range params {
+		flags = append(flags, p.flags()...)
+	}
+	return flags
+}
+
 func (f *Taskflow) registerParam(p registeredParam) {
+	if err := builtInFlagCollision(p); err != nil {
+		panic(err.Error())
+	}
+	f.addParam(p)
+}
+
+// addParam registers the parameter without checking if it collides with the built-in flags.
+func (f *Taskflow) addParam(p registeredParam) {
 	if !paramNameRegex.MatchString(p.name) {
 		panic("parameter name must match ParamNamePattern")
 	}
 	if p.newValue == nil {
 		panic("parameter is missing default value factory")
 	}
-	if _, exists := f.params[p.name]; exists {
-		panic(fmt.Sprintf("%s parameter was already registered", p.name))
+	for _, alias := range p.aliases {
+		if !paramNameRegex.MatchString(alias) {
+			panic("parameter alias must match ParamNamePattern")
+		}
+	}
+	if p.envOnly && p.envVar == "" {
+		panic(fmt.Sprintf("%s parameter is EnvOnly but has no EnvVar", p.name))
+	}
+	if err := f.paramCollision(p); err != nil {
+		panic(err.Error())
 	}
 	if f.params == nil {
 		f.params = make(map[string]registeredParam)
@@ -145,12 +332,46 @@ func (f *Taskflow) registerParam(p registeredParam) {
 }
 
 // TaskNamePattern describes the regular expression a task name must match.
-const TaskNamePattern = "^[a-zA-Z0-9_][a-zA-Z0-9_-]*$"
+const TaskNamePattern = "^[a-zA-Z0-9_][a-zA-Z0-9_:/-]*$"
 
 var taskNameRegex = regexp.MustCompile(TaskNamePattern)
 
+// builtInFlagCollision returns an error if a CLI flag of the parameter is handled by the taskflow itself.
+func builtInFlagCollision(p registeredParam) error {
+	for _, flag := range p.flags() {
+		for _, builtIn := range builtInFlags {
+			if flag == builtIn {
+				return fmt.Errorf("%s parameter flag -%s collides with a built-in flag", p.name, flag)
+			}
+		}
+	}
+	return nil
+}
+
+// paramCollision returns an error if the parameter's name or any of its CLI flags,
+// including the aliases and the -no-<name> flag of a boolean parameter,
+// is already used by a registered parameter.
+func (f *Taskflow) paramCollision(p registeredParam) error {
+	if _, exists := f.params[p.name]; exists {
+		return fmt.Errorf("%s parameter was already registered", p.name)
+	}
+	for _, flag := range p.flags() {
+		for _, other := range f.params {
+			for _, otherFlag := range other.flags() {
+				if flag == otherFlag {
+					return fmt.Errorf("%s parameter flag -%s collides with %s parameter", p.name, flag, other.name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // Register registers the task. It panics in case of any error.
 func (f *Taskflow) Register(task Task) RegisteredTask {
+	if f.parent != nil {
+		return f.registerInParent(task)
+	}
 	// validate
 	if !taskNameRegex.MatchString(task.Name) {
 		panic("task name must match TaskNamePattern")
@@ -163,32 +384,406 @@ func (f *Taskflow) Register(task Task) RegisteredTask {
 			panic(fmt.Sprintf("invalid dependency %s", dep.name))
 		}
 	}
+	for _, dep := range task.OnFailureDeps {
+		if !f.isRegistered(dep.name) {
+			panic(fmt.Sprintf("invalid on-failure dependency %s", dep.name))
+		}
+	}
+	for _, param := range task.Params {
+		if _, ok := f.params[param.Name()]; !ok {
+			panic(fmt.Sprintf("%s task references unregistered parameter %s", task.Name, param.Name()))
+		}
+	}
+
+	switch task.DependencyOrder {
+	case "", DependencyOrderStable, DependencyOrderAlpha, DependencyOrderRegistration:
+	default:
+		panic(fmt.Sprintf("%s task has invalid dependency order %s", task.Name, task.DependencyOrder))
+	}
 
+	if task.Action != nil {
+		for _, wrap := range f.wrappers {
+			task.Action = wrap(task, task.Action)
+		}
+	}
+
+	task.index = len(f.tasks)
 	f.tasks[task.Name] = task
 	return RegisteredTask{name: task.Name}
 }
 
+// Merge registers all tasks and parameters of the other taskflow.
+// The out-of-the-box parameters of the other taskflow are not copied.
+// For a sub-flow, the names of the merged tasks are prefixed like the ones registered via Register.
+// It returns an error and does not change the taskflow
+// if any task or parameter name is already registered
+// or if any CLI flag of the other taskflow's parameters collides with a registered one.
+func (f *Taskflow) Merge(other *Taskflow) error {
+	if f.parent != nil {
+		return f.mergeInParent(other)
+	}
+	builtins := map[string]bool{}
+	if other.verbosity != nil {
+		builtins[other.verbosity.Name()] = true
+	}
+	if other.workDir != nil {
+		builtins[other.workDir.Name()] = true
+	}
+	if other.plan != nil {
+		builtins[other.plan.Name()] = true
+	}
+	for name := range other.tasks {
+		if f.isRegistered(name) {
+			return fmt.Errorf("%s task was already registered", name)
+		}
+	}
+	for name, p := range other.params {
+		if builtins[name] {
+			continue
+		}
+		if err := builtInFlagCollision(p); err != nil {
+			return err
+		}
+		if err := f.paramCollision(p); err != nil {
+			return err
+		}
+	}
+
+	for name, p := range other.params {
+		if !builtins[name] {
+			f.addParam(p)
+		}
+	}
+	// the merged tasks are registered after the existing ones
+	offset := len(f.tasks)
+	for name, task := range other.tasks {
+		task.index += offset
+		f.tasks[name] = task
+	}
+	return nil
+}
+
+// WrapAction adds a middleware which wraps the actions of the tasks registered afterwards,
+// e.g. to measure or log their execution.
+// The middleware receives the task and its action and returns the action which is run instead.
+// The middlewares are applied in the order in which they were added,
+// so the last one is the outermost.
+func (f *Taskflow) WrapAction(middleware func(task Task, action func(*TF)) func(*TF)) {
+	if f.parent != nil {
+		f.parent.WrapAction(middleware)
+		return
+	}
+	f.wrappers = append(f.wrappers, middleware)
+}
+
+// actionWrapper is a middleware added using WrapAction.
+type actionWrapper func(task Task, action func(*TF)) func(*TF)
+
+// MustMerge is like Merge, but panics in case of an error.
+func (f *Taskflow) MustMerge(other *Taskflow) {
+	if err := f.Merge(other); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetOutput sets the output where text is printed.
+// If w is nil, then os.Stdout is used.
+func (f *Taskflow) SetOutput(w io.Writer) {
+	if w == nil {
+		w = os.Stdout
+	}
+	f.Output = w
+}
+
+// Writer returns the output where text is printed.
+func (f *Taskflow) Writer() io.Writer {
+	if f.Output == nil {
+		return os.Stdout
+	}
+	return f.Output
+}
+
+// Tee makes the taskflow write its output also to w,
+// e.g. to capture it in a log file while it still appears in the terminal.
+func (f *Taskflow) Tee(w io.Writer) {
+	f.Output = io.MultiWriter(f.Writer(), w)
+}
+
+// Clone returns a copy of the taskflow.
+// Registering tasks and parameters in the copy does not affect the original and vice versa.
+// The Output writer and the hooks are shared.
+// The clone of a sub-flow is a sub-flow of the clone of its parent taskflow.
+func (f *Taskflow) Clone() *Taskflow {
+	if f.parent != nil {
+		// the clone of a sub-flow registers the tasks in the clone of its parent
+		parent := f.parent.Clone()
+		clone := *f
+		clone.parent = parent
+		clone.params = parent.params
+		clone.tasks = parent.tasks
+		return &clone
+	}
+	clone := *f
+	if f.Color != nil {
+		color := *f.Color
+		clone.Color = &color
+	}
+	clone.DefaultTasks = append([]RegisteredTask(nil), f.DefaultTasks...)
+	if f.verbosity != nil {
+		verbosity := *f.verbosity
+		clone.verbosity = &verbosity
+	}
+	if f.workDir != nil {
+		workDir := *f.workDir
+		clone.workDir = &workDir
+	}
+	if f.plan != nil {
+		plan := *f.plan
+		clone.plan = &plan
+	}
+	if f.params != nil {
+		clone.params = make(map[string]registeredParam, len(f.params))
+		for name, param := range f.params {
+			clone.params[name] = param
+		}
+	}
+	if f.tasks != nil {
+		clone.tasks = make(map[string]Task, len(f.tasks))
+		for name, task := range f.tasks {
+			clone.tasks[name] = task.clone()
+		}
+	}
+	clone.listeners = append([]ExecutionListener(nil), f.listeners...)
+	clone.wrappers = append([]actionWrapper(nil), f.wrappers...)
+	clone.running = nil
+	clone.stats = f.Stats()
+	return &clone
+}
+
 // Run runs provided tasks and all their dependencies.
 // Each task is executed at most once.
 func (f *Taskflow) Run(ctx context.Context, args ...string) int {
+	return f.run(ctx, args, false)
+}
+
+// RunOnce is like Run, but it skips the tasks having the Cacheable field set
+// which passed before with the same parameter values.
+// The results of the passed tasks are stored in files in CacheDir.
+func (f *Taskflow) RunOnce(ctx context.Context, args ...string) int {
+	return f.run(ctx, args, true)
+}
+
+func (f *Taskflow) run(ctx context.Context, args []string, useCache bool) int {
+	if f.parent != nil {
+		return f.parent.run(ctx, args, useCache)
+	}
+	code := f.runWith(ctx, func(ctx context.Context, flow *flowRunner) int {
+		flow.useCache = useCache
+		return flow.Run(ctx, args)
+	})
+	f.lastArgs = append([]string(nil), args...)
+	return code
+}
+
+// runWith calls run with a new flow runner and records the results of the run.
+func (f *Taskflow) runWith(ctx context.Context, run func(ctx context.Context, flow *flowRunner) int) int {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	flow := f.runner()
+	f.running = flow
+	defer func() { f.running = nil }()
+	code := run(ctx, flow)
+	f.stats = flow.stats
+	f.taskErr = flow.taskErr
+	return code
+}
+
+// RerunFailed runs the tasks which failed in the most recent run
+// with the parameters set using the same arguments.
+// It returns CodePass if no task failed.
+func (f *Taskflow) RerunFailed(ctx context.Context) int {
+	if f.parent != nil {
+		return f.parent.RerunFailed(ctx)
+	}
+	var failed []string
+	isFailed := map[string]bool{}
+	for _, result := range f.Stats().Tasks {
+		if result.Status == "FAIL" && !isFailed[result.Name] {
+			isFailed[result.Name] = true
+			failed = append(failed, result.Name)
+		}
+	}
+	if len(failed) == 0 {
+		return CodePass
+	}
+	return f.runWith(ctx, func(ctx context.Context, flow *flowRunner) int {
+		return flow.rerun(ctx, f.lastArgs, failed)
+	})
+}
+
+// ParseResult is the result of parsing the command-line arguments.
+type ParseResult struct {
+	Tasks          []string          // the tasks provided via CLI, including "-" which stands for the tasks read from stdin
+	ParamValues    map[string]string // the values of the parameters set via CLI
+	UsageRequested bool              // usage was requested via -h, --help, or help
+}
 
+// ParseArgs parses the command-line arguments like Run, but does not run anything.
+// The environment variables of the parameters are not taken into account.
+// The "-" argument is not expanded to the task names read from stdin,
+// instead it is returned in Tasks as it is.
+func (f *Taskflow) ParseArgs(args ...string) (ParseResult, error) {
+	if f.parent != nil {
+		return f.parent.ParseArgs(args...)
+	}
+	flow := f.runner()
+	flow.output = ioutil.Discard
+	flow.literalStdin = true
+	flow.initializeParameters()
+	parsed, err := flow.parseArguments(args)
+	if err != nil {
+		return ParseResult{}, err
+	}
+	result := ParseResult{
+		Tasks:          parsed.tasks,
+		ParamValues:    map[string]string{},
+		UsageRequested: parsed.usageRequested,
+	}
+	for name := range flow.explicit {
+		result.ParamValues[name] = flow.paramValues[name].String()
+	}
+	return result, nil
+}
+
+// Stats returns the summary of the most recent Run.
+func (f *Taskflow) Stats() RunStats {
+	if f.parent != nil {
+		return f.parent.Stats()
+	}
+	stats := f.stats
+	stats.Tasks = append([]TaskResult(nil), f.stats.Tasks...)
+	return stats
+}
+
+// ExportEnv sets an environment variable named prefix+name for each registered parameter.
+// The value is the parameter's value in the ongoing run,
+// so it should be called within a task's action to reflect the values set via CLI.
+// Outside of a run, the default values are used.
+// The EnvOnly parameters, which are meant for secrets, are not exported.
+// It returns the first error returned by os.Setenv.
+func (f *Taskflow) ExportEnv(prefix string) error {
+	if f.parent != nil {
+		return f.parent.ExportEnv(prefix)
+	}
+	var paramValues map[string]ParamValue
+	if f.running != nil {
+		paramValues = f.running.paramValues
+	}
+	names := make([]string, 0, len(f.params))
+	for name, param := range f.params {
+		if param.envOnly {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, ok := paramValues[name]
+		if !ok {
+			value = f.params[name].newValue()
+		}
+		if err := os.Setenv(prefix+name, value.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute runs provided tasks and all their dependencies like Run.
+// Instead of returning the exit code, it returns a *TaskError if a task failed or was interrupted
+// or a *RunError if the exit code is different from CodePass for other reasons.
+// It is useful when os.Exit must be avoided, e.g. in tests.
+func (f *Taskflow) Execute(ctx context.Context, args ...string) error {
+	if f.parent != nil {
+		return f.parent.Execute(ctx, args...)
+	}
+	code := f.Run(ctx, args...)
+	if code == CodePass {
+		return nil
+	}
+	if f.taskErr != nil {
+		return f.taskErr
+	}
+	return &RunError{Code: code}
+}
+
+// PrintUsage writes the usage of the taskflow, which is also printed for the -h flag, to w.
+func (f *Taskflow) PrintUsage(w io.Writer) {
+	if f.parent != nil {
+		f.parent.PrintUsage(w)
+		return
+	}
+	flow := f.runner()
+	flow.output = w
+	printUsage(flow)
+}
+
+// MustExecute is like Execute but panics if the run is not successful.
+// It is useful in tests.
+func (f *Taskflow) MustExecute(ctx context.Context, args ...string) {
+	if err := f.Execute(ctx, args...); err != nil {
+		panic(err)
+	}
+}
+
+// runner returns a flowRunner for the current state of the taskflow.
+func (f *Taskflow) runner() *flowRunner {
 	flow := &flowRunner{
-		output:      f.Output,
-		params:      f.params,
-		tasks:       f.tasks,
-		verbose:     f.VerboseParam(),
-		workDir:     f.WorkDirParam(),
-		defaultTask: f.DefaultTask,
+		output:       f.Output,
+		params:       f.params,
+		tasks:        f.tasks,
+		verbosity:    f.VerbosityParam(),
+		workDir:      f.WorkDirParam(),
+		plan:         f.PlanParam(),
+		defaultTasks: f.defaultTasks(),
+		onTaskStart:  f.OnTaskStart,
+		onTaskEnd:    f.OnTaskEnd,
+		listeners:    f.listeners,
+		quiet:        f.Quiet,
+		streamAfter:  f.StreamAfter,
+		prefixOutput: f.PrefixOutput,
+		printGantt:   f.PrintGantt,
+		maxFailures:  f.MaxFailures,
+		usageWidth:   f.UsageWidth,
+		jsonLines:    f.JSONLines,
+		traceFile:    f.TraceFile,
+		reportJUnit:  f.ReportJUnit,
+		store:        &sync.Map{},
 	}
 
 	if flow.output == nil {
 		flow.output = os.Stdout
 	}
+	flow.color = useColor(f.Color, flow.output)
+	if flow.maxFailures < 1 {
+		flow.maxFailures = 1
+	}
+	if flow.usageWidth < 1 {
+		flow.usageWidth = DefaultUsageWidth
+	}
+
+	return flow
+}
 
-	return flow.Run(ctx, args)
+func (f *Taskflow) defaultTasks() []RegisteredTask {
+	if len(f.DefaultTasks) > 0 {
+		return f.DefaultTasks
+	}
+	if f.DefaultTask.name != "" {
+		return []RegisteredTask{f.DefaultTask}
+	}
+	return nil
 }
 
 func (f *Taskflow) isRegistered(name string) bool {
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,6 +34,14 @@ func Test_Register_errors(t *testing.T) {
 			desc: "invalid task name",
 			task: goyek.Task{Name: "-flag"},
 		},
+		{
+			desc: "invalid dependency order",
+			task: goyek.Task{Name: "my-task", DependencyOrder: "random"},
+		},
+		{
+			desc: "unregistered parameter",
+			task: goyek.Task{Name: "my-task", Params: goyek.Params{(&goyek.Taskflow{}).RegisterBoolParam(goyek.BoolParam{Name: "b"})}},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -174,7 +185,8 @@ func Test_skip(t *testing.T) {
 }
 
 func Test_task_panics(t *testing.T) {
-	flow := &goyek.Taskflow{}
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
 	flow.Register(goyek.Task{
 		Name: "task",
 		Action: func(tf *goyek.TF) {
@@ -185,6 +197,8 @@ func Test_task_panics(t *testing.T) {
 	exitCode := flow.Run(context.Background(), "task")
 
 	assertEqual(t, exitCode, 1, "should return error from first task")
+	assertContains(t, sb.String(), "panic: panicked!", "should print the panic value")
+	assertContains(t, sb.String(), "----- FAIL: task", "should report the task as failed")
 }
 
 func Test_cancelation(t *testing.T) {
@@ -351,20 +365,40 @@ func Test_concurrent_printing(t *testing.T) {
 }
 
 func Test_name(t *testing.T) {
+	for _, taskName := range []string{"my-named-task", "ci:lint/go_mod", "_internal", "a/b:c-d"} {
+		taskName := taskName
+		t.Run(taskName, func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			var got string
+			flow.Register(goyek.Task{
+				Name: taskName,
+				Action: func(tf *goyek.TF) {
+					got = tf.Name()
+				},
+			})
+
+			exitCode := flow.Run(context.Background(), taskName)
+
+			assertEqual(t, exitCode, 0, "should pass")
+			assertEqual(t, got, taskName, "should return the registered name")
+		})
+	}
+}
+
+func Test_name_sub(t *testing.T) {
 	flow := &goyek.Taskflow{}
-	taskName := "my-named-task"
 	var got string
-	flow.Register(goyek.Task{
-		Name: taskName,
+	flow.Sub("backend").Register(goyek.Task{
+		Name: "build",
 		Action: func(tf *goyek.TF) {
 			got = tf.Name()
 		},
 	})
 
-	exitCode := flow.Run(context.Background(), taskName)
+	exitCode := flow.Run(context.Background(), "backend/build")
 
 	assertEqual(t, exitCode, 0, "should pass")
-	assertEqual(t, got, taskName, "should return proper Name value")
+	assertEqual(t, got, "backend/build", "should return the name registered in the taskflow")
 }
 
 type arrayValue []string
@@ -486,6 +520,111 @@ func Test_unregistered_params(t *testing.T) {
 	assertEqual(t, goyek.CodeFail, exitCode, "should fail because of unregistered parameter")
 }
 
+func Test_undeclared_params(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	param := flow.RegisterStringParam(goyek.StringParam{Name: "private"})
+	flow.Register(goyek.Task{
+		Name:   "owner",
+		Params: goyek.Params{param},
+	})
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			param.Get(tf)
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail because the parameter is not declared by the task")
+}
+
+func Test_verbose_param_available(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	var got bool
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			got = flow.VerboseParam().Get(tf)
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "-v", "task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertTrue(t, got, "should return the verbose parameter's value")
+}
+
+func Test_verbosity_param(t *testing.T) {
+	tt := []struct {
+		args []string
+
+		exitCode  int
+		verbosity int
+		verbose   bool
+	}{
+		{args: []string{}, exitCode: goyek.CodePass, verbosity: 0, verbose: false},
+		{args: []string{"-v"}, exitCode: goyek.CodePass, verbosity: 1, verbose: true},
+		{args: []string{"-v=2"}, exitCode: goyek.CodePass, verbosity: 2, verbose: true},
+		{args: []string{"-v", "2"}, exitCode: goyek.CodePass, verbosity: 2, verbose: true},
+		{args: []string{"-v", "0"}, exitCode: goyek.CodePass, verbosity: 0, verbose: false},
+		{args: []string{"-v=false"}, exitCode: goyek.CodePass, verbosity: 0, verbose: false},
+
+		{args: []string{"-v=-1"}, exitCode: goyek.CodeInvalidArgs},
+		{args: []string{"-v=debug"}, exitCode: goyek.CodeInvalidArgs},
+	}
+
+	for index, tc := range tt {
+		tc := tc
+		t.Run("case "+strconv.Itoa(index), func(t *testing.T) {
+			flow := &goyek.Taskflow{Output: &strings.Builder{}}
+			var gotVerbosity int
+			var gotVerbose bool
+			flow.Register(goyek.Task{
+				Name: "task",
+				Action: func(tf *goyek.TF) {
+					gotVerbosity = flow.VerbosityParam().Get(tf)
+					gotVerbose = flow.VerboseParam().Get(tf)
+				},
+			})
+
+			exitCode := flow.Run(context.Background(), append(tc.args, "task")...)
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, gotVerbosity, tc.verbosity, "verbosity should match")
+			assertEqual(t, gotVerbose, tc.verbose, "verbose should match")
+		})
+	}
+}
+
+func Test_verbosity_debug_prints_params(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	param := flow.RegisterStringParam(goyek.StringParam{Name: "name", Default: "world"})
+	flow.Register(goyek.Task{
+		Name:   "task",
+		Action: func(tf *goyek.TF) {},
+		Params: goyek.Params{param},
+	})
+
+	exitCode := flow.Run(context.Background(), "-v=2", "task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertContains(t, sb.String(), "-name=world", "should print the parameter values")
+}
+
+func Test_verbosity_debug_prints_dependency_timing(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	dep := flow.Register(goyek.Task{Name: "dep", Action: func(tf *goyek.TF) {}})
+	flow.Register(goyek.Task{Name: "task", Deps: goyek.Deps{dep}, Action: func(tf *goyek.TF) {}})
+
+	exitCode := flow.Run(context.Background(), "-v", "2", "task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertContains(t, sb.String(), "      dependency dep of task checked (", "should print the timing of the dependency check")
+}
+
 func Test_defaultTask(t *testing.T) {
 	flow := &goyek.Taskflow{}
 	taskRan := false
@@ -549,6 +688,85 @@ func Test_wd_param_invalid(t *testing.T) {
 	assertEqual(t, afterDir, beforeDir, "should change back the working directory after taskflow")
 }
 
+func Test_task_work_dir(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	beforeDir, err := os.Getwd()
+	requireEqual(t, err, nil, "should get work dir before the taskflow")
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	var got, gotOther string
+	flow.Register(goyek.Task{
+		Name:    "task",
+		WorkDir: dir,
+		Action: func(tf *goyek.TF) {
+			var osErr error
+			got, osErr = os.Getwd()
+			requireEqual(t, osErr, nil, "should get work dir from task")
+		},
+	})
+	flow.Register(goyek.Task{
+		Name: "other",
+		Action: func(tf *goyek.TF) {
+			var osErr error
+			gotOther, osErr = os.Getwd()
+			requireEqual(t, osErr, nil, "should get work dir from task")
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task", "other")
+
+	assertEqual(t, exitCode, 0, "should pass")
+	assertEqual(t, got, dir, "should run the task in its working directory")
+	assertEqual(t, gotOther, beforeDir, "should restore the working directory after the task")
+}
+
+func Test_task_work_dir_invalid(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	taskRan := false
+	flow.Register(goyek.Task{
+		Name:    "task",
+		WorkDir: "strange-dir",
+		Action: func(tf *goyek.TF) {
+			taskRan = true
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertEqual(t, taskRan, false, "should not run the action")
+}
+
+func Test_task_environment(t *testing.T) {
+	os.Setenv("GOYEK_TEST_EXISTING", "before")
+	defer os.Unsetenv("GOYEK_TEST_EXISTING")
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	var got, gotOther []string
+	lookup := func() []string {
+		existing := os.Getenv("GOYEK_TEST_EXISTING")
+		added, ok := os.LookupEnv("GOYEK_TEST_ADDED")
+		return []string{existing, added, strconv.FormatBool(ok)}
+	}
+	flow.Register(goyek.Task{
+		Name: "task",
+		Environment: map[string]string{
+			"GOYEK_TEST_EXISTING": "overridden",
+			"GOYEK_TEST_ADDED":    "added",
+		},
+		Action: func(tf *goyek.TF) { got = lookup() },
+	})
+	flow.Register(goyek.Task{
+		Name:   "other",
+		Action: func(tf *goyek.TF) { gotOther = lookup() },
+	})
+
+	exitCode := flow.Run(context.Background(), "task", "other")
+
+	assertEqual(t, exitCode, 0, "should pass")
+	assertEqual(t, got, []string{"overridden", "added", "true"}, "should run the task with its environment")
+	assertEqual(t, gotOther, []string{"before", "", "false"}, "should restore the environment after the task")
+}
+
 func tempDir(t *testing.T) (string, func()) {
 	t.Helper()
 	dirName := t.Name() + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
@@ -561,3 +779,938 @@ func tempDir(t *testing.T) (string, func()) {
 	}
 	return dir, cleanup
 }
+
+func Test_skip_if(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	depRan := false
+	dep := flow.Register(goyek.Task{
+		Name: "dep",
+		Action: func(tf *goyek.TF) {
+			depRan = true
+		},
+	})
+	taskRan := false
+	flow.Register(goyek.Task{
+		Name: "task",
+		Deps: goyek.Deps{dep},
+		SkipIf: func(ctx context.Context) bool {
+			return true
+		},
+		Action: func(tf *goyek.TF) {
+			taskRan = true
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "-v", "task")
+
+	assertEqual(t, exitCode, 0, "should pass")
+	assertTrue(t, depRan, "dependency should have run")
+	assertEqual(t, taskRan, false, "task's action should not have run")
+	assertContains(t, sb.String(), "----- SKIP: task", "should report the task as skipped")
+}
+
+func Test_condition(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	dryRun := flow.RegisterBoolParam(goyek.BoolParam{Name: "dry-run"})
+	taskRan := false
+	flow.Register(goyek.Task{
+		Name:   "upload",
+		Params: goyek.Params{dryRun},
+		Condition: func(tf *goyek.TF) bool {
+			return !dryRun.Get(tf)
+		},
+		Action: func(tf *goyek.TF) {
+			taskRan = true
+		},
+	})
+
+	skipCode := flow.Run(context.Background(), "-v", "-dry-run", "upload")
+	skipped := !taskRan
+	runCode := flow.Run(context.Background(), "upload")
+
+	assertEqual(t, skipCode, goyek.CodePass, "should pass when skipped")
+	assertTrue(t, skipped, "task's action should not run when the condition is false")
+	assertContains(t, sb.String(), "----- SKIP: upload", "should report the task as skipped")
+	assertEqual(t, runCode, goyek.CodePass, "should pass")
+	assertTrue(t, taskRan, "task's action should run when the condition is true")
+}
+
+func Test_run_always(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	var executed int
+	notify := flow.Register(goyek.Task{
+		Name:      "notify",
+		RunAlways: true,
+		Action: func(tf *goyek.TF) {
+			executed++
+		},
+	})
+	flow.Register(goyek.Task{Name: "task-1", Deps: goyek.Deps{notify}})
+	flow.Register(goyek.Task{Name: "task-2", Deps: goyek.Deps{notify}})
+
+	exitCode := flow.Run(context.Background(), "task-1", "task-2")
+
+	assertEqual(t, exitCode, 0, "should pass")
+	assertEqual(t, executed, 2, "should run the task each time it is encountered")
+}
+
+func Test_task_hooks(t *testing.T) {
+	var got []string
+	flow := &goyek.Taskflow{
+		OnTaskStart: func(name string) {
+			got = append(got, "start "+name)
+		},
+		OnTaskEnd: func(name string, result goyek.TaskResult) {
+			got = append(got, "end "+name+" "+result.Name+" "+result.Status)
+		},
+	}
+	task1 := flow.Register(goyek.Task{
+		Name:   "task-1",
+		Action: func(tf *goyek.TF) {},
+	})
+	flow.Register(goyek.Task{
+		Name: "task-2",
+		Deps: goyek.Deps{task1},
+		Action: func(tf *goyek.TF) {
+			tf.Fail()
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task-2")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertEqual(t, got, []string{
+		"start task-1",
+		"end task-1 task-1 PASS",
+		"start task-2",
+		"end task-2 task-2 FAIL",
+	}, "should call the hooks")
+}
+
+func Test_plan_param(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	taskRan := false
+	action := func(tf *goyek.TF) {
+		taskRan = true
+	}
+	task1 := flow.Register(goyek.Task{Name: "task-1", Action: action})
+	task2 := flow.Register(goyek.Task{Name: "task-2", Action: action, Deps: goyek.Deps{task1}})
+	flow.Register(goyek.Task{Name: "all", Deps: goyek.Deps{task1, task2}})
+
+	exitCode := flow.Run(context.Background(), "-plan", "all")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, taskRan, false, "should not run any task")
+	assertEqual(t, sb.String(), "task-1\n  task-2\n    all\n", "should print the tasks in execution order")
+}
+
+func Test_Execute(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	flow.Register(goyek.Task{Name: "pass", Action: func(tf *goyek.TF) {}})
+	flow.Register(goyek.Task{Name: "fail", Action: func(tf *goyek.TF) { tf.Fail() }})
+
+	passErr := flow.Execute(context.Background(), "pass")
+	failErr := flow.Execute(context.Background(), "fail")
+	invalidErr := flow.Execute(context.Background(), "-bad-flag")
+
+	assertEqual(t, passErr, nil, "should return nil when passed")
+	assertEqual(t, failErr, &goyek.TaskError{TaskName: "fail", ExitCode: goyek.CodeFail}, "should return the failed task")
+	assertEqual(t, invalidErr, &goyek.RunError{Code: goyek.CodeInvalidArgs}, "should return the invalid arguments code")
+}
+
+func Test_Execute_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	flow.Register(goyek.Task{Name: "task", Action: func(tf *goyek.TF) { cancel() }})
+
+	err := flow.Execute(ctx, "task")
+
+	assertEqual(t, err, &goyek.TaskError{TaskName: "task", ExitCode: goyek.CodeFail, Cause: context.Canceled}, "should return the interrupted task")
+}
+
+func Test_TraceFile(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	traceFile := filepath.Join(dir, "trace.out")
+	flow := &goyek.Taskflow{
+		Output:    &strings.Builder{},
+		TraceFile: traceFile,
+	}
+	flow.Register(goyek.Task{Name: "task", Action: func(tf *goyek.TF) {}})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	info, err := os.Stat(traceFile)
+	requireEqual(t, err, nil, "should create the trace file")
+	assertTrue(t, info.Size() > 0, "should write the trace")
+}
+
+func Test_ReportJUnit(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	report := filepath.Join(dir, "report.xml")
+	flow := &goyek.Taskflow{
+		Output:      &strings.Builder{},
+		ReportJUnit: report,
+	}
+	flow.Register(goyek.Task{Name: "pass", Action: func(tf *goyek.TF) {}})
+	flow.Register(goyek.Task{Name: "skip", Action: func(tf *goyek.TF) { tf.SkipNow() }})
+	flow.Register(goyek.Task{Name: "fail", Action: func(tf *goyek.TF) { tf.Fail() }})
+
+	exitCode := flow.Run(context.Background(), "pass", "skip", "fail")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	content, err := ioutil.ReadFile(report)
+	requireEqual(t, err, nil, "should write the report")
+	got := string(content)
+	assertContains(t, got, `<testsuite name="goyek" tests="3" failures="1" skipped="1"`, "should contain the test suite")
+	assertContains(t, got, `<testcase name="pass" classname="goyek" time="`, "should contain the passed task")
+	assertContains(t, got, `<skipped message="task skipped"></skipped>`, "should contain the skipped task")
+	assertContains(t, got, `<failure message="task failed"></failure>`, "should contain the failed task")
+}
+
+func Test_SetOutput(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	sb := &strings.Builder{}
+
+	defaultWriter := flow.Writer()
+	flow.SetOutput(sb)
+	setWriter := flow.Writer()
+	flow.SetOutput(nil)
+
+	assertEqual(t, defaultWriter, os.Stdout, "should return os.Stdout by default")
+	assertEqual(t, setWriter, sb, "should return the set writer")
+	assertEqual(t, flow.Writer(), os.Stdout, "should use os.Stdout when nil is set")
+}
+
+func Test_tasks_from_stdin(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	input := filepath.Join(dir, "input")
+	err := ioutil.WriteFile(input, []byte("task-2\n task-1 "), 0600)
+	requireEqual(t, err, nil, "should write the input file")
+	stdin, err := os.Open(input) //nolint:gosec // test file
+	requireEqual(t, err, nil, "should open the input file")
+	defer stdin.Close()
+	oldStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = oldStdin }()
+
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	var got []string
+	action := func(tf *goyek.TF) {
+		got = append(got, tf.Name())
+	}
+	flow.Register(goyek.Task{Name: "task-1", Action: action})
+	flow.Register(goyek.Task{Name: "task-2", Action: action})
+	flow.Register(goyek.Task{Name: "task-3", Action: action})
+
+	exitCode := flow.Run(context.Background(), "task-3", "-")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, got, []string{"task-3", "task-2", "task-1"}, "should run the tasks read from stdin")
+}
+
+func Test_WrapAction(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	var got []string
+	flow.Register(goyek.Task{Name: "before", Action: func(tf *goyek.TF) { got = append(got, "before") }})
+	wrapper := func(name string) func(goyek.Task, func(*goyek.TF)) func(*goyek.TF) {
+		return func(task goyek.Task, action func(*goyek.TF)) func(*goyek.TF) {
+			return func(tf *goyek.TF) {
+				got = append(got, name+" "+task.Name)
+				action(tf)
+			}
+		}
+	}
+	flow.WrapAction(wrapper("inner"))
+	flow.WrapAction(wrapper("outer"))
+	flow.Register(goyek.Task{Name: "task", Action: func(tf *goyek.TF) { got = append(got, "task") }})
+
+	exitCode := flow.Run(context.Background(), "before", "task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, got, []string{"before", "outer task", "inner task", "task"}, "should wrap the actions of the tasks registered afterwards")
+}
+
+func Test_RunOnce(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	version := flow.RegisterStringParam(goyek.StringParam{Name: "version"})
+	runs := 0
+	flow.Register(goyek.Task{
+		Name:      "deps:download",
+		Params:    goyek.Params{version},
+		Cacheable: true,
+		Action: func(tf *goyek.TF) {
+			runs++
+		},
+	})
+
+	wd := "-wd=" + dir
+	flow.RunOnce(context.Background(), wd, "deps:download")
+	cachedCode := flow.RunOnce(context.Background(), wd, "deps:download")
+	cachedRuns := runs
+	flow.RunOnce(context.Background(), wd, "deps:download", "-version=2")
+	flow.Run(context.Background(), wd, "deps:download", "-version=2")
+
+	assertEqual(t, cachedCode, goyek.CodePass, "should pass when cached")
+	assertEqual(t, cachedRuns, 1, "should skip the cached task")
+	assertEqual(t, runs, 3, "should run the task when the parameters change or using Run")
+	assertEqual(t, flow.Stats().Tasks[0].Status, "PASS", "should not use the cache in Run")
+	_, err := os.Stat(filepath.Join(dir, goyek.CacheDir, "deps%3Adownload.json"))
+	assertEqual(t, err, nil, "should write the cache file")
+}
+
+func Test_RunOnce_work_dir(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	nested := filepath.Join(dir, "src", "pkg")
+	err := os.MkdirAll(nested, 0700)
+	requireEqual(t, err, nil, "should create the input directory")
+	input := filepath.Join(nested, "input.txt")
+	err = ioutil.WriteFile(input, []byte("v1"), 0600)
+	requireEqual(t, err, nil, "should write the input file")
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	runs := 0
+	flow.Register(goyek.Task{
+		Name:      "build",
+		WorkDir:   "src",
+		Cacheable: true,
+		Inputs:    []string{"p*"}, // matches the pkg directory
+		Outputs:   []string{"output.out"},
+		Action: func(tf *goyek.TF) {
+			runs++
+			if err := ioutil.WriteFile("output.out", nil, 0600); err != nil {
+				tf.Fatal(err)
+			}
+		},
+	})
+	run := func() int {
+		before := runs
+		exitCode := flow.RunOnce(context.Background(), "-wd="+dir, "build")
+		assertEqual(t, exitCode, goyek.CodePass, "should pass")
+		return runs - before
+	}
+
+	first := run()
+	cached := run()
+	err = ioutil.WriteFile(input, []byte("v2"), 0600)
+	requireEqual(t, err, nil, "should change the input file")
+	changedInput := run()
+
+	assertEqual(t, first, 1, "should run the task")
+	assertEqual(t, cached, 0, "should skip the task when the inputs in WorkDir are unchanged")
+	assertEqual(t, changedInput, 1, "should run the task when a file in a matched directory changes")
+}
+
+func Test_RunOnce_inputs_outputs(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	input := filepath.Join(dir, "input.txt")
+	err := ioutil.WriteFile(input, []byte("v1"), 0600)
+	requireEqual(t, err, nil, "should write the input file")
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	runs := 0
+	flow.Register(goyek.Task{
+		Name:      "build",
+		Cacheable: true,
+		Inputs:    []string{"*.txt"},
+		Outputs:   []string{"output.out"},
+		Action: func(tf *goyek.TF) {
+			runs++
+			if err := ioutil.WriteFile("output.out", nil, 0600); err != nil {
+				tf.Fatal(err)
+			}
+		},
+	})
+	run := func() int {
+		before := runs
+		flow.RunOnce(context.Background(), "-wd="+dir, "build")
+		return runs - before
+	}
+
+	first := run()
+	cached := run()
+	err = ioutil.WriteFile(input, []byte("v2"), 0600)
+	requireEqual(t, err, nil, "should change the input file")
+	changedInput := run()
+	err = os.Remove(filepath.Join(dir, "output.out"))
+	requireEqual(t, err, nil, "should remove the output file")
+	missingOutput := run()
+
+	assertEqual(t, first, 1, "should run the task")
+	assertEqual(t, cached, 0, "should skip the task when nothing changed")
+	assertEqual(t, changedInput, 1, "should run the task when an input file changed")
+	assertEqual(t, missingOutput, 1, "should run the task when an output file is missing")
+}
+
+func Test_RerunFailed(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}, MaxFailures: 2}
+	fix := flow.RegisterBoolParam(goyek.BoolParam{Name: "fix"})
+	var got []string
+	action := func(tf *goyek.TF) {
+		got = append(got, tf.Name())
+		if !fix.Get(tf) {
+			tf.Fail()
+		}
+	}
+	flow.Register(goyek.Task{Name: "pass", Action: func(tf *goyek.TF) { got = append(got, tf.Name()) }})
+	flow.Register(goyek.Task{Name: "fail-1", Params: goyek.Params{fix}, Action: action})
+	flow.Register(goyek.Task{Name: "fail-2", Params: goyek.Params{fix}, Action: action})
+
+	noFailuresCode := flow.RerunFailed(context.Background())
+	flow.Run(context.Background(), "fail-1", "pass", "fail-2")
+	got = nil
+	failCode := flow.RerunFailed(context.Background())
+	gotRerun := got
+	flow.Run(context.Background(), "fail-1", "-fix")
+	got = nil
+	passCode := flow.RerunFailed(context.Background())
+
+	assertEqual(t, noFailuresCode, goyek.CodePass, "should pass when nothing was run")
+	assertEqual(t, failCode, goyek.CodeFail, "should fail when the tasks fail again")
+	assertEqual(t, gotRerun, []string{"fail-1", "fail-2"}, "should rerun only the failed tasks")
+	assertEqual(t, passCode, goyek.CodePass, "should pass when no task failed")
+	assertEqual(t, got, []string(nil), "should not run any task when no task failed")
+}
+
+func Test_PrintUsage(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	param := flow.RegisterStringParam(goyek.StringParam{Name: "pkg", Default: "./..."})
+	flow.Register(goyek.Task{Name: "test", Usage: "Run tests", Params: goyek.Params{param}})
+	sb := &strings.Builder{}
+
+	flow.PrintUsage(sb)
+
+	assertContains(t, sb.String(), "Usage: [flag(s) | task(s)]...", "should print the usage header")
+	assertContains(t, sb.String(), "-pkg", "should print the parameters")
+	assertContains(t, sb.String(), "Run tests; -pkg", "should print the tasks")
+}
+
+func Test_MustExecute(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	flow.Register(goyek.Task{Name: "pass", Action: func(tf *goyek.TF) {}})
+	flow.Register(goyek.Task{Name: "fail", Action: func(tf *goyek.TF) { tf.Fail() }})
+
+	flow.MustExecute(context.Background(), "pass")
+	assertPanics(t, func() { flow.MustExecute(context.Background(), "fail") }, "should panic when a task fails")
+}
+
+func Test_defaultTasks(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	var got []string
+	action := func(tf *goyek.TF) {
+		got = append(got, tf.Name())
+	}
+	task1 := flow.Register(goyek.Task{Name: "task-1", Action: action})
+	task2 := flow.Register(goyek.Task{Name: "task-2", Action: action})
+	flow.DefaultTask = task1
+	flow.DefaultTasks = []goyek.RegisteredTask{task2, task1}
+
+	exitCode := flow.Run(context.Background())
+	flow.Run(context.Background(), "-h")
+
+	assertEqual(t, exitCode, 0, "should pass")
+	assertEqual(t, got, []string{"task-2", "task-1"}, "should run the default tasks in order")
+	assertContains(t, sb.String(), "Default tasks: task-2, task-1", "should print the default tasks in usage")
+}
+
+func Test_color(t *testing.T) {
+	testCases := []struct {
+		color bool
+		want  string
+	}{
+		{color: false, want: "----- PASS: task"},
+		{color: true, want: "----- \x1b[32mPASS\x1b[0m: task"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("Color:%v", tc.color), func(t *testing.T) {
+			sb := &strings.Builder{}
+			flow := &goyek.Taskflow{Output: sb, Color: &tc.color}
+			flow.Register(goyek.Task{Name: "task", Action: func(tf *goyek.TF) {}})
+
+			flow.Run(context.Background(), "-v", "task")
+
+			assertContains(t, sb.String(), tc.want, "should print the status")
+		})
+	}
+}
+
+func Test_Merge(t *testing.T) {
+	lib := &goyek.Taskflow{Output: &strings.Builder{}}
+	libParam := lib.RegisterStringParam(goyek.StringParam{Name: "pkg", Default: "./..."})
+	var got string
+	libTask := lib.Register(goyek.Task{
+		Name:   "test",
+		Params: goyek.Params{libParam},
+		Action: func(tf *goyek.TF) {
+			got = libParam.Get(tf)
+		},
+	})
+	lib.Run(context.Background(), "-h") // registers the out-of-the-box parameters
+	flow := &goyek.Taskflow{}
+
+	err := flow.Merge(lib)
+	flow.Register(goyek.Task{Name: "ci", Deps: goyek.Deps{libTask}})
+	exitCode := flow.Run(context.Background(), "-pkg", "./cmd/...", "ci")
+
+	requireEqual(t, err, nil, "should merge the taskflow")
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, got, "./cmd/...", "should run the merged task with the merged parameter")
+}
+
+func Test_Merge_collision(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		register      func(flow *goyek.Taskflow)
+		registerOther func(flow *goyek.Taskflow) // if nil, then register is used
+	}{
+		{
+			desc: "task",
+			register: func(flow *goyek.Taskflow) {
+				flow.Register(goyek.Task{Name: "task"})
+			},
+		},
+		{
+			desc: "parameter",
+			register: func(flow *goyek.Taskflow) {
+				flow.RegisterBoolParam(goyek.BoolParam{Name: "param"})
+			},
+		},
+		{
+			desc: "alias",
+			register: func(flow *goyek.Taskflow) {
+				flow.RegisterIntParam(goyek.IntParam{Name: "workers"})
+			},
+			registerOther: func(flow *goyek.Taskflow) {
+				flow.RegisterIntParam(goyek.IntParam{Name: "concurrency", ParamOptions: goyek.ParamOptions{Aliases: []string{"workers"}}})
+			},
+		},
+		{
+			desc: "no- flag",
+			register: func(flow *goyek.Taskflow) {
+				flow.RegisterBoolParam(goyek.BoolParam{Name: "cache"})
+			},
+			registerOther: func(flow *goyek.Taskflow) {
+				flow.RegisterStringParam(goyek.StringParam{Name: "no-cache"})
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			flow := &goyek.Taskflow{}
+			tc.register(flow)
+			other := &goyek.Taskflow{}
+			other.Register(goyek.Task{Name: "other-task"})
+			if tc.registerOther != nil {
+				tc.registerOther(other)
+			} else {
+				tc.register(other)
+			}
+
+			err := flow.Merge(other)
+			_, orderErr := flow.TopologicalOrder("other-task")
+
+			assertTrue(t, err != nil, "should return an error")
+			assertTrue(t, orderErr != nil, "should not register any task")
+			assertPanics(t, func() { flow.MustMerge(other) }, "should panic")
+		})
+	}
+}
+
+func Test_Stats(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	task1 := flow.Register(goyek.Task{Name: "task-1", Action: func(tf *goyek.TF) {}})
+	task2 := flow.Register(goyek.Task{Name: "task-2", Action: func(tf *goyek.TF) { tf.Skip() }})
+	flow.Register(goyek.Task{
+		Name:   "task-3",
+		Deps:   goyek.Deps{task1, task2},
+		Action: func(tf *goyek.TF) { tf.Fail() },
+	})
+
+	flow.Run(context.Background(), "task-3")
+	stats := flow.Stats()
+
+	got := make([]string, len(stats.Tasks))
+	for i, task := range stats.Tasks {
+		got[i] = task.Name + " " + task.Status
+	}
+	assertEqual(t, got, []string{"task-1 PASS", "task-2 SKIP", "task-3 FAIL"}, "should return the tasks' results")
+	assertTrue(t, stats.Total > 0, "should return the total duration")
+}
+
+func Test_list(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	flow.Register(goyek.Task{Name: "b-task", Usage: "described"})
+	flow.Register(goyek.Task{Name: "a-task"})
+
+	exitCode := flow.Run(context.Background(), "-list")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertEqual(t, sb.String(), "a-task\nb-task\n", "should print all task names")
+}
+
+func Test_Clone(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	taskRan := false
+	task := flow.Register(goyek.Task{
+		Name:   "task",
+		Action: func(tf *goyek.TF) { taskRan = true },
+	})
+
+	clone := flow.Clone()
+	param := clone.RegisterBoolParam(goyek.BoolParam{Name: "extra-param"})
+	clone.Register(goyek.Task{
+		Name:   "extra",
+		Deps:   goyek.Deps{task},
+		Params: goyek.Params{param},
+	})
+	cloneExitCode := clone.Run(context.Background(), "extra", "-extra-param")
+	exitCode := flow.Run(context.Background(), "extra")
+
+	assertEqual(t, cloneExitCode, goyek.CodePass, "should run the clone")
+	assertTrue(t, taskRan, "should run the cloned task")
+	assertEqual(t, exitCode, goyek.CodeInvalidArgs, "should not register the task in the original")
+	flow.Register(goyek.Task{Name: "extra"}) // panics if the task was registered in the original
+}
+
+func Test_ExportEnv(t *testing.T) {
+	os.Setenv("GOYEK_TEST_TOKEN", "secret")
+	defer os.Unsetenv("GOYEK_TEST_TOKEN")
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	param := flow.RegisterStringParam(goyek.StringParam{Name: "pkg", Default: "./..."})
+	token := flow.RegisterStringParam(goyek.StringParam{Name: "token", ParamOptions: goyek.ParamOptions{EnvVar: "GOYEK_TEST_TOKEN", EnvOnly: true}})
+	defer os.Unsetenv("GOYEK_pkg")
+	var got string
+	var gotToken bool
+	var err error
+	flow.Register(goyek.Task{
+		Name:   "task",
+		Params: goyek.Params{param, token},
+		Action: func(tf *goyek.TF) {
+			err = flow.ExportEnv("GOYEK_")
+			got = os.Getenv("GOYEK_pkg")
+			_, gotToken = os.LookupEnv("GOYEK_token")
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task", "-pkg=./cmd/...")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	requireEqual(t, err, nil, "should export the parameters")
+	assertEqual(t, got, "./cmd/...", "should export the value set via CLI")
+	assertEqual(t, gotToken, false, "should not export the EnvOnly parameter")
+}
+
+func Test_PrefixOutput(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb, PrefixOutput: true}
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			tf.Log("first line")
+			io.WriteString(tf.Output(), "second ") //nolint:errcheck // test
+			io.WriteString(tf.Output(), "line")    //nolint:errcheck // test
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "-v", "task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertContains(t, sb.String(), "===== TASK  task\n[task] first line\n[task] second line\n----- PASS", "should prefix the lines")
+}
+
+func Test_StreamAfter_fast_task(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb, StreamAfter: time.Hour}
+	passing := flow.Register(goyek.Task{
+		Name:   "passing",
+		Action: func(tf *goyek.TF) { tf.Log("hidden") },
+	})
+	flow.Register(goyek.Task{
+		Name:   "failing",
+		Deps:   goyek.Deps{passing},
+		Action: func(tf *goyek.TF) { tf.Error("shown") },
+	})
+
+	exitCode := flow.Run(context.Background(), "failing")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertTrue(t, !strings.Contains(sb.String(), "hidden"), "should not print the output of a fast passing task")
+	assertContains(t, sb.String(), "===== TASK  failing\n", "should print the output of a fast failing task")
+	assertTrue(t, !strings.Contains(sb.String(), "[failing]"), "should not prefix the output of a task which was not streamed")
+}
+
+func Test_StreamAfter_slow_task(t *testing.T) {
+	out := &streamOutput{want: "[slow] before\n", found: make(chan struct{})}
+	flow := &goyek.Taskflow{Output: out, StreamAfter: time.Nanosecond}
+	flow.Register(goyek.Task{
+		Name: "slow",
+		Action: func(tf *goyek.TF) {
+			tf.Log("before")
+			select {
+			case <-out.found:
+			case <-time.After(10 * time.Second):
+				tf.Fatal("the output is not streamed")
+			}
+			tf.Log("after")
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "slow")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertContains(t, out.String(), "[slow] ===== TASK  slow\n[slow] before\n[slow] after\n[slow] ----- PASS", "should stream the prefixed output of a slow task")
+}
+
+// streamOutput is a concurrency-safe output which closes found when want is written.
+type streamOutput struct {
+	want  string
+	found chan struct{}
+
+	mtx sync.Mutex
+	sb  strings.Builder
+}
+
+func (o *streamOutput) Write(p []byte) (int, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	had := strings.Contains(o.sb.String(), o.want)
+	n, err := o.sb.Write(p)
+	if !had && strings.Contains(o.sb.String(), o.want) {
+		close(o.found)
+	}
+	return n, err
+}
+
+func (o *streamOutput) String() string {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return o.sb.String()
+}
+
+func Test_PrintGantt(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb, PrintGantt: true}
+	first := flow.Register(goyek.Task{
+		Name:   "first",
+		Action: func(tf *goyek.TF) { time.Sleep(10 * time.Millisecond) },
+	})
+	flow.Register(goyek.Task{
+		Name:   "second",
+		Deps:   goyek.Deps{first},
+		Action: func(tf *goyek.TF) { time.Sleep(10 * time.Millisecond) },
+	})
+
+	exitCode := flow.Run(context.Background(), "second")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertContains(t, sb.String(), "Gantt chart", "should print the chart")
+	assertContains(t, sb.String(), "  first  |#", "should start with the first task")
+	assertContains(t, sb.String(), "#|", "should end with the second task")
+	assertContains(t, sb.String(), "  second |.", "should not start with the second task")
+}
+
+func Test_OnFailureDeps(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		fail     bool
+		exitCode int
+		ran      bool
+	}{
+		{desc: "task fails", fail: true, exitCode: goyek.CodeFail, ran: true},
+		{desc: "task passes", fail: false, exitCode: goyek.CodePass, ran: false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			sb := &strings.Builder{}
+			flow := &goyek.Taskflow{Output: sb}
+			cleanupRan := false
+			cleanup := flow.Register(goyek.Task{
+				Name:   "cleanup",
+				Action: func(tf *goyek.TF) { cleanupRan = true },
+			})
+			flow.Register(goyek.Task{
+				Name: "task",
+				Action: func(tf *goyek.TF) {
+					if tc.fail {
+						tf.Fail()
+					}
+				},
+				OnFailureDeps: goyek.Deps{cleanup},
+			})
+
+			exitCode := flow.Run(context.Background(), "task")
+
+			assertEqual(t, exitCode, tc.exitCode, "exit code should match")
+			assertEqual(t, cleanupRan, tc.ran, "should run the on-failure task only when the task fails")
+			assertEqual(t, strings.Contains(sb.String(), "===== ON-FAILURE  cleanup"), tc.ran, "should print the on-failure marker")
+		})
+	}
+}
+
+func Test_MaxFailures(t *testing.T) {
+	testCases := []struct {
+		maxFailures int
+		executed    []string
+		summary     string
+	}{
+		{maxFailures: 0, executed: []string{"a"}, summary: "task failed: a\t"},
+		{maxFailures: 2, executed: []string{"a", "b", "c"}, summary: "tasks failed: a, c\t"},
+		{maxFailures: 5, executed: []string{"a", "b", "c", "d"}, summary: "tasks failed: a, c\t"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run("max "+strconv.Itoa(tc.maxFailures), func(t *testing.T) {
+			sb := &strings.Builder{}
+			flow := &goyek.Taskflow{Output: sb, MaxFailures: tc.maxFailures}
+			var executed []string
+			for _, name := range []string{"a", "b", "c", "d"} {
+				name := name
+				flow.Register(goyek.Task{
+					Name: name,
+					Action: func(tf *goyek.TF) {
+						executed = append(executed, name)
+						if name == "a" || name == "c" {
+							tf.Fail()
+						}
+					},
+				})
+			}
+
+			exitCode := flow.Run(context.Background(), "a", "b", "c", "d")
+
+			assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+			assertEqual(t, executed, tc.executed, "should run the tasks until the failures limit is reached")
+			assertContains(t, sb.String(), tc.summary, "should print the failed tasks")
+		})
+	}
+}
+
+func Test_MaxFailures_failed_dependency(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb, MaxFailures: 2}
+	a := flow.Register(goyek.Task{
+		Name:   "a",
+		Action: func(tf *goyek.TF) { tf.Fail() },
+	})
+	flow.Register(goyek.Task{
+		Name: "b",
+		Deps: goyek.Deps{a},
+	})
+	cRan := false
+	flow.Register(goyek.Task{
+		Name:   "c",
+		Action: func(tf *goyek.TF) { cRan = true },
+	})
+
+	exitCode := flow.Run(context.Background(), "a", "b", "c")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertEqual(t, cRan, true, "should not count the failed dependency twice")
+	assertContains(t, sb.String(), "task failed: a\t", "should report the failed task once")
+}
+
+func Test_Tee(t *testing.T) {
+	out := &strings.Builder{}
+	log := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: out}
+	flow.Tee(log)
+	flow.Register(goyek.Task{
+		Name:   "task",
+		Action: func(tf *goyek.TF) { tf.Log("message") },
+	})
+
+	exitCode := flow.Run(context.Background(), "-v", "task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertContains(t, out.String(), "message", "should write to the output")
+	assertEqual(t, log.String(), out.String(), "should write the same to the tee writer")
+}
+
+func Test_ParseArgs(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	param := flow.RegisterStringParam(goyek.StringParam{Name: "pkg", Default: "./..."})
+	flow.Register(goyek.Task{Name: "build", Params: goyek.Params{param}})
+	flow.Register(goyek.Task{Name: "test"})
+
+	got, err := flow.ParseArgs("build", "-pkg", "./cmd/...", "-v", "test", "-", "-h")
+	_, invalidErr := flow.ParseArgs("-unknown")
+
+	requireEqual(t, err, nil, "should parse the arguments")
+	assertEqual(t, got, goyek.ParseResult{
+		Tasks:          []string{"build", "test", "-"},
+		ParamValues:    map[string]string{"pkg": "./cmd/...", "v": "1"},
+		UsageRequested: true,
+	}, "should return the parsed arguments")
+	assertTrue(t, invalidErr != nil, "should return an error for an unknown argument")
+}
+
+func Test_usage_wrapping(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb, UsageWidth: 20}
+	flow.Register(goyek.Task{
+		Name:  "task",
+		Usage: "a long usage which should be wrapped\nafter a hard line break",
+	})
+
+	flow.Run(context.Background(), "-h")
+
+	assertContains(t, sb.String(), "  task    a long usage which\n          should be wrapped\n          after a hard line\n          break\n", "should wrap the usage")
+}
+
+func Test_JSONLines(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb, JSONLines: true}
+	flow.Register(goyek.Task{
+		Name:   "task",
+		Action: func(tf *goyek.TF) { tf.Log("hello \"world\"") },
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	requireEqual(t, len(lines), 3, "should print only the events")
+	assertContains(t, lines[0], `{"event":"start","task":"task","time":"`, "should print the start event")
+	assertEqual(t, lines[1], `{"event":"log","task":"task","message":"hello \"world\""}`, "should print the log event")
+	assertContains(t, lines[2], `{"event":"end","task":"task","status":"PASS","durationMs":`, "should print the end event")
+}
+
+func Test_RunAction(t *testing.T) {
+	flow := &goyek.Taskflow{}
+	count := flow.RegisterIntParam(goyek.IntParam{Name: "count", Default: 3})
+	depRan := false
+	dep := flow.Register(goyek.Task{Name: "dep", Action: func(tf *goyek.TF) { depRan = true }})
+	var got int
+	flow.Register(goyek.Task{
+		Name:   "task",
+		Deps:   goyek.Deps{dep},
+		Params: goyek.Params{count},
+		Action: func(tf *goyek.TF) { got = count.Get(tf) },
+	})
+	flow.Register(goyek.Task{Name: "pipeline", Deps: goyek.Deps{dep}})
+
+	result, err := flow.RunAction(context.Background(), "task")
+	_, unknownErr := flow.RunAction(context.Background(), "unknown")
+	_, noActionErr := flow.RunAction(context.Background(), "pipeline")
+
+	requireEqual(t, err, nil, "should run the action")
+	assertEqual(t, result.Status, "PASS", "should pass")
+	assertEqual(t, got, 3, "should use the default parameter value")
+	assertEqual(t, depRan, false, "should not run the dependencies")
+	assertTrue(t, unknownErr != nil, "should return an error for an unknown task")
+	assertTrue(t, noActionErr != nil, "should return an error for a task without action")
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"os"
 	"runtime"
+	"sync"
+	"time"
 )
 
 // TF is a type passed to Task's Action function to manage task state.
@@ -13,12 +17,24 @@ import (
 // FailNow, Fatal, Fatalf, SkipNow, Skip, or Skipf.
 //
 // All methods must be called only from the goroutine running the
-// Action function.
+// Action function, unless Parallel was called.
 type TF struct {
 	ctx         context.Context
 	name        string
 	writer      io.Writer
 	paramValues map[string]ParamValue
+	store       *sync.Map
+	progressOut io.Writer
+	*tfState    // shared with the TF instances returned by WithValue
+}
+
+// tfState is the state of a running task's action.
+type tfState struct {
+	progress    chan float64
+	progressEnd chan struct{}
+	cleanups    []func()
+	mtx         sync.Mutex
+	parallel    bool
 	failed      bool
 	skipped     bool
 }
@@ -28,7 +44,51 @@ func (tf *TF) Context() context.Context {
 	return tf.ctx
 }
 
+// Deadline returns the time when the taskflows' run context is canceled, if any.
+// It is a shorthand for tf.Context().Deadline().
+func (tf *TF) Deadline() (deadline time.Time, ok bool) {
+	return tf.ctx.Deadline()
+}
+
+// Done returns a channel that is closed when the taskflows' run context is canceled.
+// It is a shorthand for tf.Context().Done().
+func (tf *TF) Done() <-chan struct{} {
+	return tf.ctx.Done()
+}
+
+// Err returns a non-nil error if the taskflows' run context is canceled.
+// It is a shorthand for tf.Context().Err().
+func (tf *TF) Err() error {
+	return tf.ctx.Err()
+}
+
+// Value returns the value associated with the key in the taskflows' run context
+// and reports whether it was found.
+// It is a shorthand for tf.Context().Value(key).
+func (tf *TF) Value(key interface{}) (value interface{}, ok bool) {
+	value = tf.ctx.Value(key)
+	return value, value != nil
+}
+
+// WithValue returns a TF whose context is associated with the value for the key.
+// It can be used to pass the value to the functions called by the action.
+// The returned TF reports the failures of the same task, but the original TF's context is unaffected.
+func (tf *TF) WithValue(key, value interface{}) *TF {
+	return &TF{
+		ctx:         context.WithValue(tf.ctx, key, value),
+		name:        tf.name,
+		writer:      tf.writer,
+		paramValues: tf.paramValues,
+		store:       tf.store,
+		progressOut: tf.progressOut,
+		tfState:     tf.tfState,
+	}
+}
+
 // Name returns the name of the running task.
+// It is exactly the name which the task is registered with in the taskflow
+// and which is used to run it via CLI. For a task registered via a sub-flow
+// or a TaskGroup, it includes the prefix, e.g. "backend/build".
 func (tf *TF) Name() string {
 	return tf.name
 }
@@ -38,6 +98,51 @@ func (tf *TF) Output() io.Writer {
 	return tf.writer
 }
 
+// Store stores the value for the key so that it can be loaded
+// by the tasks which are run later in the same taskflow run.
+// The key must be comparable.
+func (tf *TF) Store(key, value interface{}) {
+	tf.store.Store(key, value)
+}
+
+// Load returns the value stored for the key in the current taskflow run
+// and reports whether it was found.
+func (tf *TF) Load(key interface{}) (value interface{}, ok bool) {
+	return tf.store.Load(key)
+}
+
+// Progress returns a channel to which the action can send its progress
+// as a value from 0.0 to 1.0. Each value is printed as a line like "[name] 42%"
+// regardless of the verbose mode.
+// The channel is closed when the action returns and must not be used afterwards.
+func (tf *TF) Progress() chan<- float64 {
+	defer tf.lock()()
+	if tf.progress == nil {
+		tf.progress = make(chan float64)
+		tf.progressEnd = make(chan struct{})
+		go tf.printProgress()
+	}
+	return tf.progress
+}
+
+func (tf *TF) printProgress() {
+	defer close(tf.progressEnd)
+	for v := range tf.progress {
+		v = math.Max(0, math.Min(1, v))
+		fmt.Fprintf(tf.progressOut, "[%s] %d%%\n", tf.name, int(math.Round(v*100))) //nolint:gomnd // percentage
+	}
+}
+
+// stopProgress closes the progress channel and waits until all values are printed.
+func (tf *TF) stopProgress() {
+	defer tf.lock()()
+	if tf.progress == nil {
+		return
+	}
+	close(tf.progress)
+	<-tf.progressEnd
+}
+
 // Log formats its arguments using default formatting, analogous to Println,
 // and prints the text to Output. A final newline is added.
 // The text will be printed only if the task fails or taskflow is run in Verbose mode.
@@ -64,13 +169,33 @@ func (tf *TF) Errorf(format string, args ...interface{}) {
 	tf.Fail()
 }
 
+// Parallel allows calling the methods of TF from multiple goroutines
+// spawned by the Action function. It must be called before the goroutines are spawned.
+// FailNow, Fatal, Fatalf, SkipNow, Skip, and Skipf called from a spawned goroutine
+// stop only that goroutine. The Action function should wait for the goroutines to finish.
+func (tf *TF) Parallel() {
+	tf.parallel = true
+}
+
+// lock locks the task state if Parallel was called.
+// It returns the function which unlocks it.
+func (tf *TF) lock() func() {
+	if !tf.parallel {
+		return func() {}
+	}
+	tf.mtx.Lock()
+	return tf.mtx.Unlock
+}
+
 // Failed reports whether the function has failed.
 func (tf *TF) Failed() bool {
+	defer tf.lock()()
 	return tf.failed
 }
 
 // Fail marks the function as having failed but continues execution.
 func (tf *TF) Fail() {
+	defer tf.lock()()
 	tf.failed = true
 }
 
@@ -97,6 +222,7 @@ func (tf *TF) FailNow() {
 
 // Skipped reports whether the task was skipped.
 func (tf *TF) Skipped() bool {
+	defer tf.lock()()
 	return tf.skipped
 }
 
@@ -119,6 +245,155 @@ func (tf *TF) Skipf(format string, args ...interface{}) {
 // it is still considered to have failed.
 // Taskflow will continue at the next task.
 func (tf *TF) SkipNow() {
+	unlock := tf.lock()
 	tf.skipped = true
+	unlock()
 	runtime.Goexit()
 }
+
+// Subtask runs fn as a subtask named name, similar to testing.T.Run.
+// The subtask's TF has the same context, output, and parameters as the parent's TF.
+// Its name is the parent's name followed by a slash and the given name.
+// If the subtask fails, the parent task is marked as failed,
+// but its execution continues.
+// Subtask reports whether fn succeeded.
+func (tf *TF) Subtask(name string, fn func(tf *TF)) bool {
+	fullName := tf.name + "/" + name
+	fmt.Fprintf(tf.writer, "--- SUBTASK %s\n", fullName)
+	r := runner{
+		Ctx:         tf.ctx,
+		TaskName:    fullName,
+		ParamValues: tf.paramValues,
+		Output:      tf.writer,
+		Store:       tf.store,
+		Progress:    tf.progressOut,
+	}
+	result := r.Run(fn)
+	status := "PASS"
+	switch {
+	case result.Failed():
+		status = "FAIL"
+		tf.Fail()
+	case result.Skipped():
+		status = "SKIP"
+	}
+	fmt.Fprintf(tf.writer, "--- %s: %s (%.2fs)\n", status, fullName, result.Duration().Seconds())
+	return !result.Failed()
+}
+
+// Cleanup registers a function to be called when the action and all its subtasks complete.
+// Cleanup functions are called in last added, first called order.
+func (tf *TF) Cleanup(fn func()) {
+	defer tf.lock()()
+	tf.cleanups = append(tf.cleanups, fn)
+}
+
+func (tf *TF) runCleanups() {
+	unlock := tf.lock()
+	if len(tf.cleanups) == 0 {
+		unlock()
+		return
+	}
+	fn := tf.cleanups[len(tf.cleanups)-1]
+	tf.cleanups = tf.cleanups[:len(tf.cleanups)-1]
+	unlock()
+
+	// the remaining functions are called even if fn panics or calls FailNow or SkipNow
+	defer tf.runCleanups()
+	defer recoverPanic(tf)
+	fn()
+}
+
+// Chdir changes the current working directory to dir
+// and restores the previous one when the action completes.
+// It fails the task if the working directory cannot be changed.
+// The working directory is process-wide, therefore Chdir must not be used
+// when other goroutines depend on the working directory.
+func (tf *TF) Chdir(dir string) {
+	oldWd, err := os.Getwd()
+	if err != nil {
+		tf.Fatalf("cannot get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		tf.Fatalf("cannot change working directory: %v", err)
+	}
+	tf.Cleanup(func() {
+		if err := os.Chdir(oldWd); err != nil {
+			tf.Errorf("cannot restore working directory: %v", err)
+		}
+	})
+}
+
+// Setenv sets the environment variable
+// and restores its previous value when the action completes.
+// It fails the task if the environment variable cannot be set.
+// The environment is process-wide, therefore Setenv must not be used
+// when other goroutines depend on the environment variable.
+func (tf *TF) Setenv(key, value string) {
+	oldValue, existed := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		tf.Fatalf("cannot set environment variable: %v", err)
+	}
+	tf.Cleanup(func() {
+		var err error
+		if existed {
+			err = os.Setenv(key, oldValue)
+		} else {
+			err = os.Unsetenv(key)
+		}
+		if err != nil {
+			tf.Errorf("cannot restore environment variable: %v", err)
+		}
+	})
+}
+
+// Assert is equivalent to Error if the condition is false.
+// The optional msgAndArgs are used as the error's message.
+// A single value is formatted using default formatting,
+// multiple values are formatted using the first one as the format.
+func (tf *TF) Assert(condition bool, msgAndArgs ...interface{}) {
+	if !condition {
+		tf.Error(conditionMessage(msgAndArgs...))
+	}
+}
+
+// Require is equivalent to Fatal if the condition is false.
+// The optional msgAndArgs are used as the error's message,
+// the same way as in Assert.
+func (tf *TF) Require(condition bool, msgAndArgs ...interface{}) {
+	if !condition {
+		tf.Fatal(conditionMessage(msgAndArgs...))
+	}
+}
+
+func conditionMessage(msgAndArgs ...interface{}) string {
+	if msg := messageFromMsgAndArgs(msgAndArgs...); msg != "" {
+		return msg
+	}
+	return "condition not met"
+}
+
+// RequireNoError is equivalent to Fatal if err is not nil.
+// The optional msgAndArgs are used to prefix the error's message.
+// A single value is formatted using default formatting,
+// multiple values are formatted using the first one as the format.
+func (tf *TF) RequireNoError(err error, msgAndArgs ...interface{}) {
+	if err == nil {
+		return
+	}
+	if msg := messageFromMsgAndArgs(msgAndArgs...); msg != "" {
+		tf.Fatalf("%s: %v", msg, err)
+	}
+	tf.Fatal(err)
+}
+
+func messageFromMsgAndArgs(msgAndArgs ...interface{}) string {
+	switch len(msgAndArgs) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprint(msgAndArgs[0])
+	default:
+		return fmt.Sprintf(fmt.Sprint(msgAndArgs[0]), msgAndArgs[1:]...)
+	}
+}
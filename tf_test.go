@@ -0,0 +1,346 @@
+package goyek_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek"
+)
+
+func Test_Assert(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	executed := 0
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			tf.Assert(true, "should not fail")
+			executed++
+			tf.Assert(false, "value is %d", 42)
+			executed++
+			tf.Assert(false)
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertEqual(t, executed, 2, "should continue the action")
+	assertContains(t, sb.String(), "value is 42", "should log the message")
+	assertContains(t, sb.String(), "condition not met", "should log the default message")
+}
+
+func Test_Require(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	executed := 0
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			tf.Require(true)
+			executed++
+			tf.Require(false, "some error")
+			executed++
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertEqual(t, executed, 1, "should stop the action when the condition is false")
+	assertContains(t, sb.String(), "some error", "should log the message")
+}
+
+func Test_RequireNoError(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	executed := 0
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			tf.RequireNoError(nil, "should not fail")
+			executed++
+			tf.RequireNoError(errors.New("some error"), "cannot do %s", "something")
+			executed++
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertEqual(t, executed, 1, "should stop the action on error")
+	assertContains(t, sb.String(), "cannot do something: some error", "should log the message and the error")
+}
+
+func Test_Parallel(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			tf.Parallel()
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				i := i
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if i == 5 {
+						tf.Fatal("failed in goroutine " + strconv.Itoa(i))
+					}
+					tf.Log("goroutine " + strconv.Itoa(i))
+					_ = tf.Failed()
+				}()
+			}
+			wg.Wait()
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertContains(t, sb.String(), "failed in goroutine 5", "should log from the failing goroutine")
+	assertContains(t, sb.String(), "goroutine 9", "should not stop the other goroutines")
+}
+
+func Test_Subtask(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: sb}
+	var results []bool
+	var names []string
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			results = append(results, tf.Subtask("first", func(tf *goyek.TF) {
+				names = append(names, tf.Name())
+				tf.Fatal("subtask failed")
+			}))
+			results = append(results, tf.Subtask("second", func(tf *goyek.TF) {
+				names = append(names, tf.Name())
+			}))
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail the parent task")
+	assertEqual(t, results, []bool{false, true}, "should report whether the subtasks passed")
+	assertEqual(t, names, []string{"task/first", "task/second"}, "should name the subtasks")
+	assertContains(t, sb.String(), "--- SUBTASK task/first", "should report the subtask start")
+	assertContains(t, sb.String(), "--- FAIL: task/first", "should report the subtask failure")
+	assertContains(t, sb.String(), "--- PASS: task/second", "should report the subtask pass")
+}
+
+func Test_TF_context_methods(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	var gotDeadline time.Time
+	var gotOK bool
+	var gotErr error
+	var gotDone bool
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			gotDeadline, gotOK = tf.Deadline()
+			gotErr = tf.Err()
+			select {
+			case <-tf.Done():
+				gotDone = true
+			default:
+			}
+		},
+	})
+
+	exitCode := flow.Run(ctx, "task")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertTrue(t, gotOK, "should have a deadline")
+	assertTrue(t, gotDeadline.Equal(deadline), "should return the context's deadline")
+	assertEqual(t, gotErr, nil, "should not return an error")
+	assertEqual(t, gotDone, false, "should not be done")
+}
+
+func Test_Store_Load(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	producer := flow.Register(goyek.Task{
+		Name:   "producer",
+		Action: func(tf *goyek.TF) { tf.Store("artifact", "bin/app") },
+	})
+	var got interface{}
+	var found bool
+	flow.Register(goyek.Task{
+		Name: "consumer",
+		Deps: goyek.Deps{producer},
+		Action: func(tf *goyek.TF) {
+			got, found = tf.Load("artifact")
+		},
+	})
+	var foundInNextRun bool
+	flow.Register(goyek.Task{
+		Name: "other",
+		Action: func(tf *goyek.TF) {
+			_, foundInNextRun = tf.Load("artifact")
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "consumer")
+	flow.Run(context.Background(), "other")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertTrue(t, found, "should find the stored value")
+	assertEqual(t, got, "bin/app", "should load the stored value")
+	assertEqual(t, foundInNextRun, false, "should not share the values between runs")
+}
+
+func Test_Progress(t *testing.T) {
+	out := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: out}
+	flow.Register(goyek.Task{
+		Name: "build",
+		Action: func(tf *goyek.TF) {
+			progress := tf.Progress()
+			progress <- 0.42
+			progress <- 1
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "build")
+
+	assertEqual(t, exitCode, goyek.CodePass, "should pass")
+	assertContains(t, out.String(), "[build] 42%\n[build] 100%\n", "should print the progress when not verbose")
+}
+
+func Test_Cleanup(t *testing.T) {
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	var got []string
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			tf.Cleanup(func() { got = append(got, "first") })
+			tf.Cleanup(func() {
+				got = append(got, "second")
+				tf.FailNow()
+			})
+			tf.Cleanup(func() {
+				got = append(got, "third")
+				panic("cleanup")
+			})
+			tf.FailNow()
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail")
+	assertEqual(t, got, []string{"third", "second", "first"}, "should call all cleanup functions in reverse order")
+}
+
+func Test_Chdir(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	wd, err := os.Getwd()
+	requireEqual(t, err, nil, "should get the working directory")
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	var taskWd string
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			tf.Chdir(dir)
+			taskWd, _ = os.Getwd()
+		},
+	})
+	flow.Register(goyek.Task{
+		Name: "invalid",
+		Action: func(tf *goyek.TF) {
+			tf.Chdir(filepath.Join(dir, "not-existing"))
+		},
+	})
+
+	passCode := flow.Run(context.Background(), "task")
+	gotWd, _ := os.Getwd()
+	failCode := flow.Run(context.Background(), "invalid")
+
+	assertEqual(t, passCode, goyek.CodePass, "should pass")
+	assertEqual(t, taskWd, dir, "should change the working directory")
+	assertEqual(t, gotWd, wd, "should restore the working directory")
+	assertEqual(t, failCode, goyek.CodeFail, "should fail when the directory does not exist")
+}
+
+func Test_Progress_quiet(t *testing.T) {
+	out := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: out, Quiet: true}
+	flow.Register(goyek.Task{
+		Name:   "pass",
+		Action: func(tf *goyek.TF) { tf.Progress() <- 0.5 },
+	})
+	flow.Register(goyek.Task{
+		Name: "fail",
+		Action: func(tf *goyek.TF) {
+			tf.Progress() <- 0.25
+			tf.Fail()
+		},
+	})
+
+	flow.Run(context.Background(), "pass")
+	passOut := out.String()
+	out.Reset()
+	flow.Run(context.Background(), "fail")
+
+	assertTrue(t, strings.HasPrefix(passOut, "ok\t"), "should print only the summary for a passed task")
+	assertTrue(t, !strings.Contains(passOut, "50%"), "should not print the progress of a passed task")
+	assertContains(t, out.String(), "[fail] 25%", "should print the progress of a failed task")
+}
+
+func Test_Quiet(t *testing.T) {
+	out := &strings.Builder{}
+	flow := &goyek.Taskflow{Output: out, Quiet: true, PrintGantt: true}
+	old := flow.RegisterBoolParam(goyek.BoolParam{Name: "old", ParamOptions: goyek.ParamOptions{Deprecated: "do not use"}})
+	cleanup := flow.Register(goyek.Task{Name: "cleanup"})
+	flow.Register(goyek.Task{
+		Name:          "fail",
+		Params:        goyek.Params{old},
+		Action:        func(tf *goyek.TF) { tf.Fail() },
+		OnFailureDeps: goyek.Deps{cleanup},
+	})
+
+	flow.Run(context.Background(), "-old", "fail")
+
+	assertTrue(t, !strings.Contains(out.String(), "deprecated"), "should not print the deprecation warning")
+	assertTrue(t, !strings.Contains(out.String(), "ON-FAILURE"), "should not print the on-failure marker")
+	assertTrue(t, !strings.Contains(out.String(), "Gantt chart"), "should not print the Gantt chart")
+	assertContains(t, out.String(), "----- FAIL: fail", "should print the output of the failed task")
+}
+
+func Test_WithValue(t *testing.T) {
+	type ctxKey struct{}
+	flow := &goyek.Taskflow{Output: &strings.Builder{}}
+	var got interface{}
+	var found, foundInOriginal bool
+	flow.Register(goyek.Task{
+		Name: "task",
+		Action: func(tf *goyek.TF) {
+			helper := func(tf *goyek.TF) {
+				got, found = tf.Value(ctxKey{})
+				tf.Fail()
+			}
+			helper(tf.WithValue(ctxKey{}, "value"))
+			_, foundInOriginal = tf.Value(ctxKey{})
+		},
+	})
+
+	exitCode := flow.Run(context.Background(), "task")
+
+	assertEqual(t, exitCode, goyek.CodeFail, "should fail the task via the returned TF")
+	assertTrue(t, found, "should find the value")
+	assertEqual(t, got, "value", "should return the value")
+	assertEqual(t, foundInOriginal, false, "should not affect the original TF")
+}
@@ -0,0 +1,23 @@
+package goyek
+
+import (
+	"os"
+	"runtime/trace"
+)
+
+// startTrace starts writing the runtime execution trace to the file.
+// The returned function stops the tracing and closes the file.
+func startTrace(path string) (stop func() error, err error) {
+	file, err := os.Create(path) //nolint:gosec // the path is provided by the taskflow's author
+	if err != nil {
+		return nil, err
+	}
+	if err := trace.Start(file); err != nil {
+		file.Close() //nolint // the trace error is more important
+		return nil, err
+	}
+	return func() error {
+		trace.Stop()
+		return file.Close()
+	}, nil
+}